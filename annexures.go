@@ -2,6 +2,11 @@
 
 package growwapi
 
+import (
+	"fmt"
+	"slices"
+)
+
 // OrderStatus - https://groww.in/trade-api/docs/curl/annexures#order-status
 type OrderStatus string
 
@@ -43,8 +48,187 @@ const (
 	OrderStatusCompleted OrderStatus = "COMPLETED"
 )
 
+// orderStatusTransitions lists the statuses an order can move to from each status.
+// Orders can only move from EXECUTED through the settlement statuses DELIVERY_AWAITED and COMPLETED.
+// Statuses are polled, so intermediate ones might never be observed, see orderStatusReachable.
+var orderStatusTransitions = map[OrderStatus][]OrderStatus{
+	OrderStatusNew: {
+		OrderStatusAcked, OrderStatusTriggerPending, OrderStatusApproved, OrderStatusExecuted,
+		OrderStatusModificationRequested, OrderStatusCancellationRequested, OrderStatusCancelled,
+		OrderStatusRejected, OrderStatusFailed,
+	},
+	OrderStatusAcked: {
+		OrderStatusTriggerPending, OrderStatusApproved, OrderStatusExecuted,
+		OrderStatusModificationRequested, OrderStatusCancellationRequested, OrderStatusCancelled,
+		OrderStatusRejected, OrderStatusFailed,
+	},
+	OrderStatusTriggerPending: {
+		OrderStatusAcked, OrderStatusApproved, OrderStatusExecuted,
+		OrderStatusModificationRequested, OrderStatusCancellationRequested, OrderStatusCancelled,
+		OrderStatusRejected, OrderStatusFailed,
+	},
+	OrderStatusApproved: {
+		OrderStatusAcked, OrderStatusExecuted,
+		OrderStatusModificationRequested, OrderStatusCancellationRequested, OrderStatusCancelled,
+		OrderStatusRejected, OrderStatusFailed,
+	},
+	OrderStatusModificationRequested: {
+		OrderStatusAcked, OrderStatusTriggerPending, OrderStatusApproved, OrderStatusExecuted,
+		OrderStatusCancellationRequested, OrderStatusCancelled, OrderStatusRejected, OrderStatusFailed,
+	},
+	OrderStatusCancellationRequested: {
+		// cancellation can be rejected by the exchange, in which case the order remains open
+		OrderStatusAcked, OrderStatusTriggerPending, OrderStatusApproved, OrderStatusExecuted,
+		OrderStatusCancelled, OrderStatusRejected, OrderStatusFailed,
+	},
+	OrderStatusExecuted:        {OrderStatusDeliveryAwaited, OrderStatusCompleted},
+	OrderStatusDeliveryAwaited: {OrderStatusCompleted},
+	OrderStatusRejected:        {},
+	OrderStatusFailed:          {},
+	OrderStatusCancelled:       {},
+	OrderStatusCompleted:       {},
+}
+
+// orderStatusReachable holds the statuses an order can reach from each status through orderStatusTransitions
+var orderStatusReachable = reachableStatuses(orderStatusTransitions)
+
+// reachableStatuses returns the transitive closure of transitions: the statuses reachable from each status in one
+// or more steps
+func reachableStatuses[S comparable](transitions map[S][]S) map[S]map[S]bool {
+	out := make(map[S]map[S]bool, len(transitions))
+	for from := range transitions {
+		reachable := make(map[S]bool)
+		queue := slices.Clone(transitions[from])
+
+		for len(queue) > 0 {
+			next := queue[0]
+			queue = queue[1:]
+
+			if !reachable[next] {
+				reachable[next] = true
+				queue = append(queue, transitions[next]...)
+			}
+		}
+
+		out[from] = reachable
+	}
+
+	return out
+}
+
+// IsKnown returns true if the status is one of the documented OrderStatus
+func (s OrderStatus) IsKnown() bool {
+	_, ok := orderStatusTransitions[s]
+	return ok
+}
+
+// Validate returns UnknownOrderStatusError if the status is not one of the documented OrderStatus
+func (s OrderStatus) Validate() error {
+	if !s.IsKnown() {
+		return UnknownOrderStatusError{Status: s}
+	}
+
+	return nil
+}
+
+// IsTerminal returns true if the order is done at the exchange and no further fills can happen.
+// This is the case for EXECUTED, DELIVERY_AWAITED, COMPLETED, CANCELLED, REJECTED and FAILED.
+func (s OrderStatus) IsTerminal() bool {
+	switch s {
+	case OrderStatusExecuted,
+		OrderStatusDeliveryAwaited,
+		OrderStatusCompleted,
+		OrderStatusCancelled,
+		OrderStatusRejected,
+		OrderStatusFailed:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsFilled returns true if the order has been completely executed
+func (s OrderStatus) IsFilled() bool {
+	return s == OrderStatusExecuted || s == OrderStatusDeliveryAwaited || s == OrderStatusCompleted
+}
+
+// IsOpen returns true if the order is live at the exchange and can still get filled
+func (s OrderStatus) IsOpen() bool {
+	return s.IsKnown() && !s.IsTerminal()
+}
+
+// IsCancellable returns true if the order can be cancelled using Client.CancelOrder
+func (s OrderStatus) IsCancellable() bool {
+	switch s {
+	case OrderStatusNew,
+		OrderStatusAcked,
+		OrderStatusTriggerPending,
+		OrderStatusApproved,
+		OrderStatusModificationRequested:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsModifiable returns true if the order can be modified using Client.ModifyOrder
+func (s OrderStatus) IsModifiable() bool {
+	switch s {
+	case OrderStatusNew,
+		OrderStatusAcked,
+		OrderStatusTriggerPending,
+		OrderStatusApproved:
+		return true
+	default:
+		return false
+	}
+}
+
+// CanTransitionTo returns true if an order can move from s to next, directly or through statuses in between which
+// were not observed, e.g. from ACKED to COMPLETED. Staying in the same status is always allowed
+func (s OrderStatus) CanTransitionTo(next OrderStatus) bool {
+	return s == next || orderStatusReachable[s][next]
+}
+
+// ValidateTransition returns UnknownOrderStatusError if either status is unknown,
+// and InvalidOrderStatusTransitionError if an order can not move from s to next
+func (s OrderStatus) ValidateTransition(next OrderStatus) error {
+	if err := s.Validate(); err != nil {
+		return err
+	}
+
+	if err := next.Validate(); err != nil {
+		return err
+	}
+
+	if !s.CanTransitionTo(next) {
+		return InvalidOrderStatusTransitionError{From: s, To: next}
+	}
+
+	return nil
+}
+
+// UnknownOrderStatusError is returned when the API returns an OrderStatus which is not documented
+type UnknownOrderStatusError struct {
+	Status OrderStatus
+}
+
+func (e UnknownOrderStatusError) Error() string {
+	return fmt.Sprintf("unknown order status %q", e.Status)
+}
+
+// InvalidOrderStatusTransitionError is returned when an order moves between statuses in a way which is not allowed
+type InvalidOrderStatusTransitionError struct {
+	From OrderStatus
+	To   OrderStatus
+}
+
+func (e InvalidOrderStatusTransitionError) Error() string {
+	return fmt.Sprintf("invalid order status transition %s -> %s", e.From, e.To)
+}
+
 // AfterMarketOrderStatus - https://groww.in/trade-api/docs/curl/annexures#after-market-order-status
-type AfterMarketOrderStatus = OrderStatus
+type AfterMarketOrderStatus string
 
 const (
 	// AfterMarketOrderStatusNa - Status not available
@@ -69,6 +253,101 @@ const (
 	AfterMarketOrderStatusMarket AfterMarketOrderStatus = "MARKET"
 )
 
+// afterMarketOrderStatusTransitions lists the statuses an after market order can move to from each status.
+// PLACED hands the order over to the exchange, after which OrderStatus tracks it.
+// NA and MARKET are reported for orders which were not placed after market.
+var afterMarketOrderStatusTransitions = map[AfterMarketOrderStatus][]AfterMarketOrderStatus{
+	AfterMarketOrderStatusPending: {
+		AfterMarketOrderStatusParked, AfterMarketOrderStatusDispatched, AfterMarketOrderStatusPlaced,
+		AfterMarketOrderStatusFailed,
+	},
+	AfterMarketOrderStatusParked: {
+		AfterMarketOrderStatusPending, AfterMarketOrderStatusDispatched, AfterMarketOrderStatusPlaced,
+		AfterMarketOrderStatusFailed,
+	},
+	AfterMarketOrderStatusDispatched: {AfterMarketOrderStatusPlaced, AfterMarketOrderStatusFailed},
+	AfterMarketOrderStatusPlaced:     {},
+	AfterMarketOrderStatusFailed:     {},
+	AfterMarketOrderStatusNa:         {},
+	AfterMarketOrderStatusMarket:     {},
+}
+
+// afterMarketOrderStatusReachable holds the statuses an after market order can reach from each status through
+// afterMarketOrderStatusTransitions
+var afterMarketOrderStatusReachable = reachableStatuses(afterMarketOrderStatusTransitions)
+
+// IsKnown returns true if the status is one of the documented AfterMarketOrderStatus
+func (s AfterMarketOrderStatus) IsKnown() bool {
+	_, ok := afterMarketOrderStatusTransitions[s]
+	return ok
+}
+
+// Validate returns UnknownAfterMarketOrderStatusError if the status is not one of the documented
+// AfterMarketOrderStatus
+func (s AfterMarketOrderStatus) Validate() error {
+	if !s.IsKnown() {
+		return UnknownAfterMarketOrderStatusError{Status: s}
+	}
+
+	return nil
+}
+
+// IsPending returns true if the after market order is yet to be sent to the exchange.
+// This is the case for PENDING, PARKED and DISPATCHED.
+func (s AfterMarketOrderStatus) IsPending() bool {
+	return s == AfterMarketOrderStatusPending || s == AfterMarketOrderStatusParked || s == AfterMarketOrderStatusDispatched
+}
+
+// IsTerminal returns true if the status will not change anymore. This is the case for every known status
+// which is not pending, see AfterMarketOrderStatus.IsPending
+func (s AfterMarketOrderStatus) IsTerminal() bool {
+	return s.IsKnown() && !s.IsPending()
+}
+
+// CanTransitionTo returns true if an after market order can move from s to next, directly or through statuses in
+// between which were not observed. Staying in the same status is always allowed
+func (s AfterMarketOrderStatus) CanTransitionTo(next AfterMarketOrderStatus) bool {
+	return s == next || afterMarketOrderStatusReachable[s][next]
+}
+
+// ValidateTransition returns UnknownAfterMarketOrderStatusError if either status is unknown,
+// and InvalidAfterMarketOrderStatusTransitionError if an after market order can not move from s to next
+func (s AfterMarketOrderStatus) ValidateTransition(next AfterMarketOrderStatus) error {
+	if err := s.Validate(); err != nil {
+		return err
+	}
+
+	if err := next.Validate(); err != nil {
+		return err
+	}
+
+	if !s.CanTransitionTo(next) {
+		return InvalidAfterMarketOrderStatusTransitionError{From: s, To: next}
+	}
+
+	return nil
+}
+
+// UnknownAfterMarketOrderStatusError is returned when the API returns an AfterMarketOrderStatus which is not documented
+type UnknownAfterMarketOrderStatusError struct {
+	Status AfterMarketOrderStatus
+}
+
+func (e UnknownAfterMarketOrderStatusError) Error() string {
+	return fmt.Sprintf("unknown after market order status %q", e.Status)
+}
+
+// InvalidAfterMarketOrderStatusTransitionError is returned when an after market order moves between statuses in a way
+// which is not allowed
+type InvalidAfterMarketOrderStatusTransitionError struct {
+	From AfterMarketOrderStatus
+	To   AfterMarketOrderStatus
+}
+
+func (e InvalidAfterMarketOrderStatusTransitionError) Error() string {
+	return fmt.Sprintf("invalid after market order status transition %s -> %s", e.From, e.To)
+}
+
 // Exchange - https://groww.in/trade-api/docs/curl/annexures#exchange
 type Exchange string
 
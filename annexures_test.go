@@ -0,0 +1,87 @@
+package growwapi
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestOrderStatusValidateTransition(t *testing.T) {
+	tests := []struct {
+		from, to OrderStatus
+		valid    bool
+	}{
+		{from: OrderStatusNew, to: OrderStatusAcked, valid: true},
+		{from: OrderStatusAcked, to: OrderStatusAcked, valid: true},
+		{from: OrderStatusAcked, to: OrderStatusExecuted, valid: true},
+		{from: OrderStatusExecuted, to: OrderStatusDeliveryAwaited, valid: true},
+
+		// intermediate statuses which were not observed between two polls
+		{from: OrderStatusAcked, to: OrderStatusCompleted, valid: true},
+		{from: OrderStatusAcked, to: OrderStatusDeliveryAwaited, valid: true},
+		{from: OrderStatusNew, to: OrderStatusCompleted, valid: true},
+		{from: OrderStatusTriggerPending, to: OrderStatusDeliveryAwaited, valid: true},
+		{from: OrderStatusModificationRequested, to: OrderStatusDeliveryAwaited, valid: true},
+		{from: OrderStatusCancellationRequested, to: OrderStatusCompleted, valid: true},
+		{from: OrderStatusApproved, to: OrderStatusTriggerPending, valid: true},
+
+		{from: OrderStatusCancellationRequested, to: OrderStatusRejected, valid: true},
+		{from: OrderStatusCancellationRequested, to: OrderStatusAcked, valid: true},
+
+		{from: OrderStatusExecuted, to: OrderStatusAcked},
+		{from: OrderStatusDeliveryAwaited, to: OrderStatusExecuted},
+		{from: OrderStatusCompleted, to: OrderStatusDeliveryAwaited},
+		{from: OrderStatusCancelled, to: OrderStatusAcked},
+		{from: OrderStatusRejected, to: OrderStatusExecuted},
+		{from: OrderStatusFailed, to: OrderStatusCancelled},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.from)+"->"+string(tt.to), func(t *testing.T) {
+			if got := tt.from.CanTransitionTo(tt.to); got != tt.valid {
+				t.Errorf("CanTransitionTo() = %v, want %v", got, tt.valid)
+			}
+
+			err := tt.from.ValidateTransition(tt.to)
+			if tt.valid && err != nil {
+				t.Errorf("ValidateTransition() error = %v", err)
+			}
+
+			var transitionErr InvalidOrderStatusTransitionError
+			if !tt.valid && !errors.As(err, &transitionErr) {
+				t.Errorf("ValidateTransition() error = %v, want InvalidOrderStatusTransitionError", err)
+			}
+		})
+	}
+}
+
+func TestOrderStatusValidateTransitionUnknown(t *testing.T) {
+	var unknownErr UnknownOrderStatusError
+
+	if err := OrderStatusAcked.ValidateTransition("PARTIALLY_FILLED"); !errors.As(err, &unknownErr) {
+		t.Errorf("ValidateTransition() error = %v, want UnknownOrderStatusError", err)
+	}
+
+	if err := OrderStatus("").ValidateTransition(OrderStatusAcked); !errors.As(err, &unknownErr) {
+		t.Errorf("ValidateTransition() error = %v, want UnknownOrderStatusError", err)
+	}
+}
+
+func TestAfterMarketOrderStatusCanTransitionTo(t *testing.T) {
+	tests := []struct {
+		from, to AfterMarketOrderStatus
+		valid    bool
+	}{
+		{from: AfterMarketOrderStatusPending, to: AfterMarketOrderStatusDispatched, valid: true},
+		{from: AfterMarketOrderStatusParked, to: AfterMarketOrderStatusPlaced, valid: true},
+		{from: AfterMarketOrderStatusPlaced, to: AfterMarketOrderStatusPending},
+		{from: AfterMarketOrderStatusDispatched, to: AfterMarketOrderStatusParked},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.from)+"->"+string(tt.to), func(t *testing.T) {
+			if got := tt.from.CanTransitionTo(tt.to); got != tt.valid {
+				t.Errorf("CanTransitionTo() = %v, want %v", got, tt.valid)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package growwapi
+
+import "context"
+
+// API represents all the modules of Groww APIs implemented by this library. It is implemented by Client.
+// Decorators such as RiskGate can wrap the module interfaces individually and be combined back into an API.
+type API interface {
+	OrdersAPI
+	LiveDataAPI
+	BacktestingAPI
+	InstrumentsAPI
+}
+
+// OrdersAPI represents the Orders module of Groww APIs.
+// It is implemented by Client, and by PaperClient, RiskGate and JournaledOrders which can wrap each other
+//
+// https://groww.in/trade-api/docs/curl/orders
+type OrdersAPI interface {
+	PlaceOrder(ctx context.Context, req PlaceOrderRequest) (PlaceOrderResponse, error)
+	ModifyOrder(ctx context.Context, req ModifyOrderRequest) (ModifyOrderResponse, error)
+	CancelOrder(ctx context.Context, req CancelOrderRequest) (CancelOrderResponse, error)
+	GetTradesForOrder(ctx context.Context, req TradesForOrderRequest) ([]Trade, error)
+	GetOrderStatus(ctx context.Context, req OrderStatusRequest) (OrderStatusResponse, error)
+	ListOrders(ctx context.Context, req ListOrdersRequest) ([]Order, error)
+	GetOrderDetails(ctx context.Context, req GetOrderDetailsRequest) (Order, error)
+}
+
+// LiveDataAPI represents the Live Data module of Groww APIs. It is implemented by Client
+//
+// https://groww.in/trade-api/docs/curl/live-data
+type LiveDataAPI interface {
+	GetQuote(ctx context.Context, req QuoteRequest) (Quote, error)
+	GetLtp(ctx context.Context, req LtpRequest) (Ltp, error)
+	GetOhlc(ctx context.Context, req OhlcRequest) (OhlcResponse, error)
+	GetGreeks(ctx context.Context, req GetGreeksRequest) (Greeks, error)
+}
+
+// BacktestingAPI represents the Backtesting module of Groww APIs. It is implemented by Client
+//
+// https://groww.in/trade-api/docs/curl/backtesting
+type BacktestingAPI interface {
+	GetExpiries(ctx context.Context, req GetExpiriesRequest) (GetExpiriesResponse, error)
+	GetContracts(ctx context.Context, req GetContractsRequest) (GetContractsResponse, error)
+	GetHistoricalCandles(ctx context.Context, req GetHistoricalCandlesRequest) (HistoricalCandlesData, error)
+}
+
+// InstrumentsAPI represents the Instruments module of Groww APIs. It is implemented by Client
+//
+// https://groww.in/trade-api/docs/curl/instruments
+type InstrumentsAPI interface {
+	Instruments(ctx context.Context) ([]Instrument, error)
+}
+
+var (
+	_ API         = (*Client)(nil)
+	_ OrdersAPI   = (*PaperClient)(nil)
+	_ OrdersAPI   = (*RiskGate)(nil)
+	_ OrdersAPI   = (*JournaledOrders)(nil)
+	_ QuoteSource = LiveDataAPI(nil)
+)
@@ -0,0 +1,432 @@
+// Package backtest replays historical candles through a trading strategy and simulates the orders it places.
+//
+// The Engine merges the candle series of all instruments and feeds them to a Strategy in timestamp order. Orders are
+// placed with growwapi.PlaceOrderRequest, and are matched against the candles of their instrument which come after
+// the candle they were placed on, so a strategy never trades on prices it has not seen yet. Orders are filled in full,
+// and the engine keeps track of positions, cash and profit and loss.
+//
+// Margin is not simulated: orders are never rejected for lack of cash, which goes negative when buying more than it
+// covers, and short positions are allowed in every segment.
+package backtest
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rctrj/growwapi-go"
+)
+
+// Symbol identifies an instrument the way growwapi.PlaceOrderRequest does
+type Symbol struct {
+	Exchange      growwapi.Exchange
+	Segment       growwapi.Segment
+	TradingSymbol string
+}
+
+func (s Symbol) String() string {
+	return fmt.Sprintf("%s-%s-%s", s.Exchange, s.Segment, s.TradingSymbol)
+}
+
+// Series represents the candles of an instrument, sorted by timestamp
+type Series struct {
+	Symbol  Symbol
+	Candles []growwapi.Candle
+}
+
+// Strategy is driven by the Engine. The engine is passed to every call to place and cancel orders, and to read
+// positions and cash.
+type Strategy interface {
+	// OnCandle is called with every candle, after the open orders of its instrument were matched against it
+	OnCandle(engine *Engine, symbol Symbol, candle growwapi.Candle)
+	// OnFill is called when an order is filled, before OnOrderUpdate
+	OnFill(engine *Engine, fill Fill)
+	// OnOrderUpdate is called when the status of an order changes after it was placed
+	OnOrderUpdate(engine *Engine, order Order)
+}
+
+// Config represents the configuration of an Engine
+type Config struct {
+	// Cash at the start of the backtest
+	InitialCash float64
+	// [Optional] Fraction of the price market orders, including triggered SL_M orders, are filled worse by,
+	// e.g. 0.0005 for 5 basis points. Buys are filled higher and sells lower.
+	Slippage float64
+	// [Optional] Commission charged for a fill, deducted from cash. No commission is charged if nil.
+	Commission func(fill Fill) float64
+	// [Optional] Whether CNC and NRML orders expire at the end of their session like MIS orders do.
+	// By default they stay open until they are filled or cancelled, carrying over to the next sessions.
+	ExpireDeliveryOrders bool
+}
+
+// EquityPoint represents the equity of the backtest at a timestamp
+type EquityPoint struct {
+	Timestamp time.Time
+	// Cash plus the market value of all positions
+	Equity float64
+}
+
+// Result represents the outcome of Engine.Run
+type Result struct {
+	// Cash at the end
+	Cash float64
+	// Equity at the end
+	Equity float64
+	// Positions at the end, including the ones which were closed
+	Positions []Position
+	// All the orders placed, in the order they were placed
+	Orders []Order
+	// All the fills, in the order they happened
+	Fills []Fill
+	// Equity after every timestamp of the candles
+	EquityCurve []EquityPoint
+}
+
+// Engine replays candles through a Strategy. Create it with New, and use it for a single Run.
+type Engine struct {
+	config  Config
+	series  []Series
+	symbols map[Symbol]bool
+	// daily holds the symbols whose candles are a day or more apart
+	daily map[Symbol]bool
+
+	now       time.Time
+	cash      float64
+	positions map[Symbol]*Position
+	orders    []*Order
+	byId      map[string]*Order
+	fills     []Fill
+	// sessions holds the IST date of the session an expiring order is valid for, by order id
+	sessions map[string]string
+}
+
+// New creates an Engine replaying the candles of series
+func New(config Config, series ...Series) *Engine {
+	symbols := make(map[Symbol]bool)
+	daily := make(map[Symbol]bool)
+	for _, s := range series {
+		symbols[s.Symbol] = true
+		daily[s.Symbol] = isDaily(s.Candles)
+	}
+
+	return &Engine{
+		config:    config,
+		series:    series,
+		symbols:   symbols,
+		daily:     daily,
+		cash:      config.InitialCash,
+		positions: make(map[Symbol]*Position),
+		byId:      make(map[string]*Order),
+		sessions:  make(map[string]string),
+	}
+}
+
+// isDaily reports whether consecutive candles are at least a day apart, as daily and weekly candles are
+func isDaily(candles []growwapi.Candle) bool {
+	if len(candles) < 2 {
+		return false
+	}
+
+	for i := 1; i < len(candles); i++ {
+		if candles[i].Timestamp.Sub(candles[i-1].Timestamp.Time) < 24*time.Hour {
+			return false
+		}
+	}
+
+	return true
+}
+
+// replayEvent is a candle of a series
+type replayEvent struct {
+	symbol Symbol
+	candle growwapi.Candle
+}
+
+// Run replays all the candles through strategy, in timestamp order. Candles with the same timestamp are replayed in
+// the order of their series. Run stops early if ctx is done.
+func (e *Engine) Run(ctx context.Context, strategy Strategy) (Result, error) {
+	var events []replayEvent
+	for _, series := range e.series {
+		for _, candle := range series.Candles {
+			events = append(events, replayEvent{symbol: series.Symbol, candle: candle})
+		}
+	}
+
+	slices.SortStableFunc(events, func(a, b replayEvent) int {
+		return a.candle.Timestamp.Compare(b.candle.Timestamp.Time)
+	})
+
+	var curve []EquityPoint
+	for i, event := range events {
+		if err := ctx.Err(); err != nil {
+			return Result{}, err
+		}
+
+		e.now = event.candle.Timestamp.Time
+		e.matchOrders(strategy, event.symbol, event.candle)
+
+		position := e.position(event.symbol)
+		position.LastPrice = float64(event.candle.Close)
+
+		strategy.OnCandle(e, event.symbol, event.candle)
+
+		if i == len(events)-1 || !events[i+1].candle.Timestamp.Equal(e.now) {
+			curve = append(curve, EquityPoint{Timestamp: e.now, Equity: e.Equity()})
+		}
+	}
+
+	return Result{
+		Cash:        e.cash,
+		Equity:      e.Equity(),
+		Positions:   e.Positions(),
+		Orders:      e.Orders(),
+		Fills:       slices.Clone(e.fills),
+		EquityCurve: curve,
+	}, nil
+}
+
+// Now returns the timestamp of the candle being replayed
+func (e *Engine) Now() time.Time {
+	return e.now
+}
+
+// PlaceOrder places an order, which is matched from the next candle of its instrument.
+//
+// Validity is ignored as DAY is the only one. MIS orders, and CNC and NRML ones if Config.ExpireDeliveryOrders is
+// set, are valid for a session: orders which are still open are cancelled on the first candle of their instrument on a
+// later IST date. Orders placed on daily candles are valid for the session of the next candle, which they are matched
+// against before they expire. Other orders stay open until they are filled or cancelled.
+func (e *Engine) PlaceOrder(req growwapi.PlaceOrderRequest) (Order, error) {
+	if err := validateOrder(req); err != nil {
+		return Order{}, fmt.Errorf("invalid order: %w", err)
+	}
+
+	if symbol := (Order{Request: req}).Symbol(); !e.symbols[symbol] {
+		return Order{}, fmt.Errorf("no candles for %s", symbol)
+	}
+
+	order := &Order{
+		Id:        fmt.Sprintf("BT%08d", len(e.orders)+1),
+		Request:   req,
+		Status:    growwapi.OrderStatusAcked,
+		PlacedAt:  e.now,
+		UpdatedAt: e.now,
+	}
+
+	if req.OrderType == growwapi.OrderTypeStopLoss || req.OrderType == growwapi.OrderTypeStopLossMarket {
+		order.Status = growwapi.OrderStatusTriggerPending
+	}
+
+	e.orders = append(e.orders, order)
+	e.byId[order.Id] = order
+	return *order, nil
+}
+
+// CancelOrder cancels an open order
+func (e *Engine) CancelOrder(id string) (Order, error) {
+	order, ok := e.byId[id]
+	if !ok {
+		return Order{}, fmt.Errorf("order %s not found", id)
+	}
+
+	if !order.IsOpen() {
+		return *order, fmt.Errorf("order %s is %s", id, order.Status)
+	}
+
+	delete(e.sessions, id)
+	order.Status = growwapi.OrderStatusCancelled
+	order.UpdatedAt = e.now
+	order.Remark = "cancelled by strategy"
+	return *order, nil
+}
+
+// Order returns an order by id
+func (e *Engine) Order(id string) (Order, bool) {
+	order, ok := e.byId[id]
+	if !ok {
+		return Order{}, false
+	}
+
+	return *order, true
+}
+
+// Orders returns all the orders placed, in the order they were placed
+func (e *Engine) Orders() []Order {
+	out := make([]Order, len(e.orders))
+	for i, order := range e.orders {
+		out[i] = *order
+	}
+
+	return out
+}
+
+// OpenOrders returns the orders which can still be filled, in the order they were placed
+func (e *Engine) OpenOrders() []Order {
+	var out []Order
+	for _, order := range e.orders {
+		if order.IsOpen() {
+			out = append(out, *order)
+		}
+	}
+
+	return out
+}
+
+// Position returns the position in an instrument, which is flat if it was never traded
+func (e *Engine) Position(symbol Symbol) Position {
+	if position, ok := e.positions[symbol]; ok {
+		return *position
+	}
+
+	return Position{Symbol: symbol}
+}
+
+// Positions returns the positions in all the instruments replayed so far, sorted by symbol
+func (e *Engine) Positions() []Position {
+	out := make([]Position, 0, len(e.positions))
+	for _, symbol := range slices.SortedFunc(maps.Keys(e.positions), compareSymbols) {
+		out = append(out, *e.positions[symbol])
+	}
+
+	return out
+}
+
+// Cash returns the cash available
+func (e *Engine) Cash() float64 {
+	return e.cash
+}
+
+// Equity returns the cash plus the market value of all positions at the last close of their instruments
+func (e *Engine) Equity() float64 {
+	equity := e.cash
+	for _, position := range e.positions {
+		equity += position.MarketValue()
+	}
+
+	return equity
+}
+
+func (e *Engine) position(symbol Symbol) *Position {
+	position, ok := e.positions[symbol]
+	if !ok {
+		position = &Position{Symbol: symbol}
+		e.positions[symbol] = position
+	}
+
+	return position
+}
+
+// matchOrders matches the open orders of symbol placed before candle against it, after cancelling the ones whose
+// session ended
+func (e *Engine) matchOrders(strategy Strategy, symbol Symbol, candle growwapi.Candle) {
+	date := candle.Timestamp.In(growwapi.IST).Format(time.DateOnly)
+
+	// orders placed by callbacks are matched from the next candle
+	for _, order := range slices.Clone(e.orders) {
+		if !order.IsOpen() || order.Symbol() != symbol || !candle.Timestamp.After(order.PlacedAt) {
+			continue
+		}
+
+		if session, ok := e.session(order, date); ok && session != date {
+			delete(e.sessions, order.Id)
+			order.Status = growwapi.OrderStatusCancelled
+			order.UpdatedAt = e.now
+			order.Remark = "validity expired"
+			strategy.OnOrderUpdate(e, *order)
+			continue
+		}
+
+		triggered, price, filled, slips := match(order, candle)
+		if !filled {
+			if triggered {
+				order.Status = growwapi.OrderStatusAcked
+				order.UpdatedAt = e.now
+				order.Remark = "triggered"
+				strategy.OnOrderUpdate(e, *order)
+			}
+
+			continue
+		}
+
+		if slips {
+			price = e.slip(order.Request.TransactionType, price)
+		}
+
+		fill := Fill{
+			OrderId:         order.Id,
+			Symbol:          symbol,
+			TransactionType: order.Request.TransactionType,
+			Quantity:        order.Request.Quantity,
+			Price:           price,
+			Timestamp:       e.now,
+		}
+
+		if e.config.Commission != nil {
+			fill.Commission = e.config.Commission(fill)
+		}
+
+		if fill.TransactionType == growwapi.TransactionTypeBuy {
+			e.cash -= fill.Value()
+		} else {
+			e.cash += fill.Value()
+		}
+
+		e.cash -= fill.Commission
+		e.position(symbol).apply(fill)
+		e.fills = append(e.fills, fill)
+
+		delete(e.sessions, order.Id)
+		order.Status = growwapi.OrderStatusExecuted
+		order.FilledQuantity = fill.Quantity
+		order.AveragePrice = fill.Price
+		order.UpdatedAt = e.now
+		order.Remark = ""
+
+		strategy.OnFill(e, fill)
+		strategy.OnOrderUpdate(e, *order)
+	}
+}
+
+// session returns the IST date of the session order is valid for, given the date of the first candle it is matched
+// against, and false if the order does not expire
+func (e *Engine) session(order *Order, date string) (string, bool) {
+	if order.Request.Product != growwapi.ProductMis && !e.config.ExpireDeliveryOrders {
+		return "", false
+	}
+
+	if session, ok := e.sessions[order.Id]; ok {
+		return session, true
+	}
+
+	// the next session starts with the next daily candle, while intraday candles of a later date mean it ended
+	session := order.PlacedAt.In(growwapi.IST).Format(time.DateOnly)
+	if e.daily[order.Symbol()] {
+		session = date
+	}
+
+	e.sessions[order.Id] = session
+	return session, true
+}
+
+func (e *Engine) slip(transactionType growwapi.TransactionType, price float64) float64 {
+	if transactionType == growwapi.TransactionTypeBuy {
+		return price * (1 + e.config.Slippage)
+	}
+
+	return price * (1 - e.config.Slippage)
+}
+
+func compareSymbols(a, b Symbol) int {
+	if a.Exchange != b.Exchange {
+		return strings.Compare(string(a.Exchange), string(b.Exchange))
+	}
+
+	if a.Segment != b.Segment {
+		return strings.Compare(string(a.Segment), string(b.Segment))
+	}
+
+	return strings.Compare(a.TradingSymbol, b.TradingSymbol)
+}
@@ -0,0 +1,434 @@
+package backtest
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/rctrj/growwapi-go"
+)
+
+var reliance = Symbol{Exchange: growwapi.ExchangeNse, Segment: growwapi.SegmentCash, TradingSymbol: "RELIANCE"}
+
+// script is a Strategy calling onCandle with every candle, recording the fills and order updates
+type script struct {
+	onCandle func(engine *Engine, index int)
+	candles  int
+	fills    []Fill
+	updates  []Order
+}
+
+func (s *script) OnCandle(engine *Engine, _ Symbol, _ growwapi.Candle) {
+	if s.onCandle != nil {
+		s.onCandle(engine, s.candles)
+	}
+
+	s.candles++
+}
+
+func (s *script) OnFill(_ *Engine, fill Fill) {
+	s.fills = append(s.fills, fill)
+}
+
+func (s *script) OnOrderUpdate(_ *Engine, order Order) {
+	s.updates = append(s.updates, order)
+}
+
+// candle returns a candle of given open, high, low and close
+func candle(timestamp time.Time, open, high, low, close float32) growwapi.Candle {
+	return growwapi.Candle{
+		Timestamp: growwapi.Time{Time: timestamp},
+		Ohlcv:     growwapi.Ohlcv{Ohlc: growwapi.Ohlc{Open: open, High: high, Low: low, Close: close}},
+	}
+}
+
+// fiveMinuteCandles returns candles 5 minutes apart from 09:15 IST on 2024-01-01, given as open, high, low, close
+func fiveMinuteCandles(ohlc ...[4]float32) []growwapi.Candle {
+	start := time.Date(2024, time.January, 1, 9, 15, 0, 0, growwapi.IST)
+
+	out := make([]growwapi.Candle, len(ohlc))
+	for i, c := range ohlc {
+		out[i] = candle(start.Add(time.Duration(i)*5*time.Minute), c[0], c[1], c[2], c[3])
+	}
+
+	return out
+}
+
+func orderRequest(
+	transactionType growwapi.TransactionType,
+	orderType growwapi.OrderType,
+	quantity int,
+	price, triggerPrice float32,
+) growwapi.PlaceOrderRequest {
+	return growwapi.PlaceOrderRequest{
+		TradingSymbol:   reliance.TradingSymbol,
+		Quantity:        quantity,
+		Price:           price,
+		TriggerPrice:    triggerPrice,
+		Validity:        growwapi.ValidityDay,
+		Exchange:        reliance.Exchange,
+		Segment:         reliance.Segment,
+		Product:         growwapi.ProductMis,
+		OrderType:       orderType,
+		TransactionType: transactionType,
+	}
+}
+
+func assertPrice(t *testing.T, name string, got, want float64) {
+	t.Helper()
+
+	if math.Abs(got-want) > 1e-9 {
+		t.Errorf("%s = %v, want %v", name, got, want)
+	}
+}
+
+func TestMatch(t *testing.T) {
+	buy, sell := growwapi.TransactionTypeBuy, growwapi.TransactionTypeSell
+
+	tests := []struct {
+		name string
+		req  growwapi.PlaceOrderRequest
+		// status after the candle, and the fill price if executed
+		status growwapi.OrderStatus
+		price  float64
+	}{
+		{
+			name:   "market buy at the open with slippage",
+			req:    orderRequest(buy, growwapi.OrderTypeMarket, 1, 0, 0),
+			status: growwapi.OrderStatusExecuted,
+			price:  101,
+		},
+		{
+			name:   "market sell at the open with slippage",
+			req:    orderRequest(sell, growwapi.OrderTypeMarket, 1, 0, 0),
+			status: growwapi.OrderStatusExecuted,
+			price:  99,
+		},
+		{
+			name:   "limit buy above the open at the open",
+			req:    orderRequest(buy, growwapi.OrderTypeLimit, 1, 101, 0),
+			status: growwapi.OrderStatusExecuted,
+			price:  100,
+		},
+		{
+			name:   "limit buy within the candle at the limit",
+			req:    orderRequest(buy, growwapi.OrderTypeLimit, 1, 98, 0),
+			status: growwapi.OrderStatusExecuted,
+			price:  98,
+		},
+		{
+			name:   "limit buy below the low",
+			req:    orderRequest(buy, growwapi.OrderTypeLimit, 1, 90, 0),
+			status: growwapi.OrderStatusAcked,
+		},
+		{
+			name:   "limit sell within the candle at the limit",
+			req:    orderRequest(sell, growwapi.OrderTypeLimit, 1, 104, 0),
+			status: growwapi.OrderStatusExecuted,
+			price:  104,
+		},
+		{
+			name:   "stop loss market sell triggered within the candle at the trigger with slippage",
+			req:    orderRequest(sell, growwapi.OrderTypeStopLossMarket, 1, 0, 99),
+			status: growwapi.OrderStatusExecuted,
+			price:  99 * 0.99,
+		},
+		{
+			name:   "stop loss market sell triggered by the open at the open with slippage",
+			req:    orderRequest(sell, growwapi.OrderTypeStopLossMarket, 1, 0, 101),
+			status: growwapi.OrderStatusExecuted,
+			price:  99,
+		},
+		{
+			name:   "stop loss market buy triggered within the candle at the trigger with slippage",
+			req:    orderRequest(buy, growwapi.OrderTypeStopLossMarket, 1, 0, 104),
+			status: growwapi.OrderStatusExecuted,
+			price:  104 * 1.01,
+		},
+		{
+			name:   "stop loss market buy above the high",
+			req:    orderRequest(buy, growwapi.OrderTypeStopLossMarket, 1, 0, 110),
+			status: growwapi.OrderStatusTriggerPending,
+		},
+		{
+			name:   "stop loss buy triggered within the candle at the trigger",
+			req:    orderRequest(buy, growwapi.OrderTypeStopLoss, 1, 104.5, 104),
+			status: growwapi.OrderStatusExecuted,
+			price:  104,
+		},
+		{
+			name:   "stop loss buy triggered within the candle with the limit below the trigger",
+			req:    orderRequest(buy, growwapi.OrderTypeStopLoss, 1, 103, 104),
+			status: growwapi.OrderStatusAcked,
+		},
+		{
+			name:   "stop loss sell triggered by the open with the limit above the high",
+			req:    orderRequest(sell, growwapi.OrderTypeStopLoss, 1, 108, 110),
+			status: growwapi.OrderStatusAcked,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			candles := fiveMinuteCandles([4]float32{100, 100, 100, 100}, [4]float32{100, 105, 95, 102})
+
+			var id string
+			strategy := &script{onCandle: func(engine *Engine, index int) {
+				if index > 0 {
+					return
+				}
+
+				order, err := engine.PlaceOrder(tt.req)
+				if err != nil {
+					t.Fatalf("PlaceOrder() error = %v", err)
+				}
+
+				id = order.Id
+			}}
+
+			engine := New(Config{InitialCash: 1000, Slippage: 0.01}, Series{Symbol: reliance, Candles: candles})
+			if _, err := engine.Run(context.Background(), strategy); err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+
+			order, _ := engine.Order(id)
+			if order.Status != tt.status {
+				t.Fatalf("Status = %s, want %s", order.Status, tt.status)
+			}
+
+			if tt.status != growwapi.OrderStatusExecuted {
+				if len(strategy.fills) != 0 {
+					t.Errorf("got fills %+v, want none", strategy.fills)
+				}
+
+				return
+			}
+
+			if len(strategy.fills) != 1 {
+				t.Fatalf("got %d fills, want 1", len(strategy.fills))
+			}
+
+			fill := strategy.fills[0]
+			assertPrice(t, "fill Price", fill.Price, tt.price)
+			assertPrice(t, "AveragePrice", order.AveragePrice, tt.price)
+
+			if !fill.Timestamp.Equal(candles[1].Timestamp.Time) {
+				t.Errorf("fill Timestamp = %v, want %v", fill.Timestamp, candles[1].Timestamp)
+			}
+		})
+	}
+}
+
+func TestStopLossTriggeredThenFilled(t *testing.T) {
+	candles := fiveMinuteCandles(
+		[4]float32{100, 100, 100, 100},
+		[4]float32{100, 105, 95, 102},
+		[4]float32{104, 104, 102, 103},
+	)
+
+	strategy := &script{onCandle: func(engine *Engine, index int) {
+		if index == 0 {
+			_, _ = engine.PlaceOrder(orderRequest(growwapi.TransactionTypeBuy, growwapi.OrderTypeStopLoss, 1, 103, 104))
+		}
+	}}
+
+	engine := New(Config{InitialCash: 1000}, Series{Symbol: reliance, Candles: candles})
+	if _, err := engine.Run(context.Background(), strategy); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	// triggered on the second candle, which went above the limit afterward, then filled at the limit on the third
+	if len(strategy.updates) != 2 {
+		t.Fatalf("got %d updates, want 2", len(strategy.updates))
+	}
+
+	if update := strategy.updates[0]; update.Status != growwapi.OrderStatusAcked || update.Remark != "triggered" {
+		t.Errorf("first update = %s %q, want ACKED triggered", update.Status, update.Remark)
+	}
+
+	if update := strategy.updates[1]; update.Status != growwapi.OrderStatusExecuted {
+		t.Errorf("second update = %s, want EXECUTED", update.Status)
+	}
+
+	assertPrice(t, "AveragePrice", strategy.updates[1].AveragePrice, 103)
+}
+
+func TestPositionPnl(t *testing.T) {
+	candles := fiveMinuteCandles(
+		[4]float32{100, 100, 100, 100},
+		[4]float32{101, 102, 100, 101},
+		[4]float32{103, 105, 102, 104},
+		[4]float32{100, 101, 97, 98},
+	)
+
+	strategy := &script{onCandle: func(engine *Engine, index int) {
+		var req growwapi.PlaceOrderRequest
+		switch index {
+		case 0:
+			req = orderRequest(growwapi.TransactionTypeBuy, growwapi.OrderTypeMarket, 10, 0, 0)
+		case 1:
+			req = orderRequest(growwapi.TransactionTypeSell, growwapi.OrderTypeLimit, 10, 104, 0)
+		case 2:
+			req = orderRequest(growwapi.TransactionTypeSell, growwapi.OrderTypeStopLossMarket, 5, 0, 99)
+		default:
+			return
+		}
+
+		if _, err := engine.PlaceOrder(req); err != nil {
+			t.Fatalf("PlaceOrder() error = %v", err)
+		}
+	}}
+
+	config := Config{InitialCash: 10000, Slippage: 0.01, Commission: func(Fill) float64 { return 1 }}
+	result, err := New(config, Series{Symbol: reliance, Candles: candles}).Run(context.Background(), strategy)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	// bought 10 at 101*1.01 = 102.01, sold 10 at 104 and shorted 5 at 99*0.99 = 98.01, with 1 of commission each
+	wantFills := []float64{102.01, 104, 98.01}
+	if len(result.Fills) != len(wantFills) {
+		t.Fatalf("got %d fills, want %d", len(result.Fills), len(wantFills))
+	}
+
+	for i, want := range wantFills {
+		assertPrice(t, "fill Price", result.Fills[i].Price, want)
+	}
+
+	if len(result.Positions) != 1 {
+		t.Fatalf("got %d positions, want 1", len(result.Positions))
+	}
+
+	position := result.Positions[0]
+	if position.Quantity != -5 {
+		t.Errorf("Quantity = %d, want -5", position.Quantity)
+	}
+
+	assertPrice(t, "AveragePrice", position.AveragePrice, 98.01)
+	assertPrice(t, "RealizedPnl", position.RealizedPnl, 10*(104-102.01))
+	assertPrice(t, "LastPrice", position.LastPrice, 98)
+	assertPrice(t, "UnrealizedPnl", position.UnrealizedPnl(), -5*(98-98.01))
+
+	cash := 10000 - 1020.1 + 1040 + 490.05 - 3
+	assertPrice(t, "Cash", result.Cash, cash)
+	assertPrice(t, "Equity", result.Equity, cash-5*98)
+
+	if len(result.EquityCurve) != len(candles) {
+		t.Fatalf("got %d equity points, want %d", len(result.EquityCurve), len(candles))
+	}
+
+	// cash less the commission and the position at the close of 101
+	assertPrice(t, "Equity after the buy", result.EquityCurve[1].Equity, 10000-1020.1-1+10*101)
+}
+
+func TestOrderExpiry(t *testing.T) {
+	day := time.Date(2024, time.January, 1, 0, 0, 0, 0, growwapi.IST)
+	intraday := []growwapi.Candle{
+		candle(day.Add(15*time.Hour+20*time.Minute), 100, 100, 100, 100),
+		candle(day.Add(15*time.Hour+25*time.Minute), 100, 101, 99, 100),
+		candle(day.AddDate(0, 0, 1).Add(9*time.Hour+15*time.Minute), 89, 92, 85, 90),
+	}
+	daily := []growwapi.Candle{
+		candle(day, 100, 100, 100, 100),
+		candle(day.AddDate(0, 0, 1), 99, 101, 96, 100),
+		candle(day.AddDate(0, 0, 2), 95, 96, 90, 92),
+	}
+
+	limitBuy := func(product growwapi.Product, price float32) growwapi.PlaceOrderRequest {
+		req := orderRequest(growwapi.TransactionTypeBuy, growwapi.OrderTypeLimit, 1, price, 0)
+		req.Product = product
+		return req
+	}
+
+	tests := []struct {
+		name                 string
+		candles              []growwapi.Candle
+		expireDeliveryOrders bool
+		req                  growwapi.PlaceOrderRequest
+		// status at the end, and the timestamp of the candle the order was filled or cancelled on
+		status    growwapi.OrderStatus
+		updatedAt time.Time
+	}{
+		{
+			name:      "intraday MIS order expires on the next day",
+			candles:   intraday,
+			req:       limitBuy(growwapi.ProductMis, 95),
+			status:    growwapi.OrderStatusCancelled,
+			updatedAt: intraday[2].Timestamp.Time,
+		},
+		{
+			name:      "intraday CNC order carries over to the next day",
+			candles:   intraday,
+			req:       limitBuy(growwapi.ProductCnc, 95),
+			status:    growwapi.OrderStatusExecuted,
+			updatedAt: intraday[2].Timestamp.Time,
+		},
+		{
+			name:                 "intraday CNC order expires with ExpireDeliveryOrders",
+			candles:              intraday,
+			expireDeliveryOrders: true,
+			req:                  limitBuy(growwapi.ProductCnc, 95),
+			status:               growwapi.OrderStatusCancelled,
+			updatedAt:            intraday[2].Timestamp.Time,
+		},
+		{
+			name:      "daily MIS order fills on the next candle",
+			candles:   daily,
+			req:       limitBuy(growwapi.ProductMis, 97),
+			status:    growwapi.OrderStatusExecuted,
+			updatedAt: daily[1].Timestamp.Time,
+		},
+		{
+			name:      "daily MIS order expires after the next candle",
+			candles:   daily,
+			req:       limitBuy(growwapi.ProductMis, 93),
+			status:    growwapi.OrderStatusCancelled,
+			updatedAt: daily[2].Timestamp.Time,
+		},
+		{
+			name:      "daily NRML order carries over",
+			candles:   daily,
+			req:       limitBuy(growwapi.ProductNormal, 93),
+			status:    growwapi.OrderStatusExecuted,
+			updatedAt: daily[2].Timestamp.Time,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var id string
+			strategy := &script{onCandle: func(engine *Engine, index int) {
+				if index > 0 {
+					return
+				}
+
+				order, err := engine.PlaceOrder(tt.req)
+				if err != nil {
+					t.Fatalf("PlaceOrder() error = %v", err)
+				}
+
+				id = order.Id
+			}}
+
+			config := Config{InitialCash: 1000, ExpireDeliveryOrders: tt.expireDeliveryOrders}
+			engine := New(config, Series{Symbol: reliance, Candles: tt.candles})
+			if _, err := engine.Run(context.Background(), strategy); err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+
+			order, _ := engine.Order(id)
+			if order.Status != tt.status {
+				t.Errorf("Status = %s, want %s", order.Status, tt.status)
+			}
+
+			if !order.UpdatedAt.Equal(tt.updatedAt) {
+				t.Errorf("UpdatedAt = %v, want %v", order.UpdatedAt, tt.updatedAt)
+			}
+
+			if tt.status == growwapi.OrderStatusCancelled && order.Remark != "validity expired" {
+				t.Errorf("Remark = %q, want %q", order.Remark, "validity expired")
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package backtest
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rctrj/growwapi-go"
+)
+
+// Order represents an order placed in a backtest
+type Order struct {
+	// Id generated by the engine
+	Id string
+	// Request the order was placed with
+	Request growwapi.PlaceOrderRequest
+	// Current status: ACKED while working, TRIGGER_PENDING for stop loss orders waiting for their trigger price,
+	// then EXECUTED or CANCELLED
+	Status growwapi.OrderStatus
+	// Quantity filled, either 0 or the quantity of the order
+	FilledQuantity int
+	// Price the order was filled at
+	AveragePrice float64
+	// Time of the candle being processed when the order was placed
+	PlacedAt time.Time
+	// Time of the candle the order last changed on
+	UpdatedAt time.Time
+	// Remark on the last change, such as why the order was cancelled
+	Remark string
+}
+
+// IsOpen reports whether the order can still be filled
+func (o Order) IsOpen() bool {
+	return o.Status == growwapi.OrderStatusAcked || o.Status == growwapi.OrderStatusTriggerPending
+}
+
+// Symbol returns the instrument of the order
+func (o Order) Symbol() Symbol {
+	return Symbol{Exchange: o.Request.Exchange, Segment: o.Request.Segment, TradingSymbol: o.Request.TradingSymbol}
+}
+
+func validateOrder(req growwapi.PlaceOrderRequest) error {
+	if req.Quantity <= 0 {
+		return fmt.Errorf("quantity must be positive, got %d", req.Quantity)
+	}
+
+	if req.TransactionType != growwapi.TransactionTypeBuy && req.TransactionType != growwapi.TransactionTypeSell {
+		return fmt.Errorf("invalid transaction type %q", req.TransactionType)
+	}
+
+	switch req.OrderType {
+	case growwapi.OrderTypeMarket:
+	case growwapi.OrderTypeLimit:
+		if req.Price <= 0 {
+			return fmt.Errorf("LIMIT order needs a price")
+		}
+	case growwapi.OrderTypeStopLoss:
+		if req.Price <= 0 || req.TriggerPrice <= 0 {
+			return fmt.Errorf("SL order needs a price and a trigger price")
+		}
+	case growwapi.OrderTypeStopLossMarket:
+		if req.TriggerPrice <= 0 {
+			return fmt.Errorf("SL_M order needs a trigger price")
+		}
+	default:
+		return fmt.Errorf("invalid order type %q", req.OrderType)
+	}
+
+	return nil
+}
+
+// match simulates the order on a candle. It returns whether the order was triggered on the candle, and the price it
+// was filled at, if it was, before slippage. Whether slippage applies is returned as well.
+//
+// Candles only tell the range prices moved in, so the open is taken as the first price, and a price within the range
+// as reached: orders are filled at the open when it is already good enough, else at their limit or trigger price.
+func match(order *Order, candle growwapi.Candle) (triggered bool, price float64, filled, slips bool) {
+	buy := order.Request.TransactionType == growwapi.TransactionTypeBuy
+	open := float64(candle.Open)
+
+	// favorable is the best price in the candle for the order, adverse the worst
+	favorable, adverse := float64(candle.Low), float64(candle.High)
+	if !buy {
+		favorable, adverse = adverse, favorable
+	}
+
+	// atLeastAsGood reports whether a is as good a price as b for the order
+	atLeastAsGood := func(a, b float64) bool {
+		if buy {
+			return a <= b
+		}
+
+		return a >= b
+	}
+
+	limit := float64(order.Request.Price)
+	fillLimit := func() (float64, bool) {
+		switch {
+		case atLeastAsGood(open, limit):
+			return open, true
+		case atLeastAsGood(favorable, limit):
+			return limit, true
+		default:
+			return 0, false
+		}
+	}
+
+	switch order.Request.OrderType {
+	case growwapi.OrderTypeMarket:
+		return false, open, true, true
+	case growwapi.OrderTypeLimit:
+		price, filled = fillLimit()
+		return false, price, filled, false
+	}
+
+	// stop loss orders, which trigger once the price moves against them to the trigger price
+	if order.Status == growwapi.OrderStatusAcked {
+		price, filled = fillLimit()
+		return false, price, filled, false
+	}
+
+	trigger := float64(order.Request.TriggerPrice)
+	var triggeredAt float64
+	switch {
+	case atLeastAsGood(trigger, open):
+		triggeredAt = open
+	case atLeastAsGood(trigger, adverse):
+		triggeredAt = trigger
+	default:
+		return false, 0, false, false
+	}
+
+	if order.Request.OrderType == growwapi.OrderTypeStopLossMarket {
+		return true, triggeredAt, true, true
+	}
+
+	if triggeredAt == open {
+		price, filled = fillLimit()
+		return true, price, filled, false
+	}
+
+	// triggered within the candle, where only the trigger price is known to have been reached afterward
+	if atLeastAsGood(trigger, limit) {
+		return true, trigger, true, false
+	}
+
+	return true, 0, false, false
+}
@@ -0,0 +1,95 @@
+package backtest
+
+import (
+	"time"
+
+	"github.com/rctrj/growwapi-go"
+)
+
+// Fill represents the execution of an order
+type Fill struct {
+	// Id of the filled order
+	OrderId string
+	// Instrument traded
+	Symbol Symbol
+	// Buy or sell
+	TransactionType growwapi.TransactionType
+	// Quantity traded. Orders are always filled entirely.
+	Quantity int
+	// Price traded at, including slippage
+	Price float64
+	// Commission charged, see Config.Commission
+	Commission float64
+	// Timestamp of the candle the order was filled on
+	Timestamp time.Time
+}
+
+// Value returns the traded value, price times quantity
+func (f Fill) Value() float64 {
+	return f.Price * float64(f.Quantity)
+}
+
+// Position represents the holding of an instrument
+type Position struct {
+	// Instrument held
+	Symbol Symbol
+	// Net quantity, negative for short positions
+	Quantity int
+	// Average price the open quantity was traded at. 0 if the position is flat.
+	AveragePrice float64
+	// Profit and loss of the quantity which was closed, before commissions
+	RealizedPnl float64
+	// Close of the last candle of the instrument
+	LastPrice float64
+}
+
+// UnrealizedPnl returns the profit and loss of the open quantity at LastPrice
+func (p Position) UnrealizedPnl() float64 {
+	return float64(p.Quantity) * (p.LastPrice - p.AveragePrice)
+}
+
+// MarketValue returns the value of the open quantity at LastPrice, negative for short positions
+func (p Position) MarketValue() float64 {
+	return float64(p.Quantity) * p.LastPrice
+}
+
+// apply updates the position with a fill, realizing the profit and loss of the quantity it closes
+func (p *Position) apply(fill Fill) {
+	quantity := fill.Quantity
+	if fill.TransactionType == growwapi.TransactionTypeSell {
+		quantity = -quantity
+	}
+
+	switch {
+	case p.Quantity == 0 || (p.Quantity > 0) == (quantity > 0):
+		total := abs(p.Quantity) + abs(quantity)
+		p.AveragePrice = (p.AveragePrice*float64(abs(p.Quantity)) + fill.Price*float64(abs(quantity))) / float64(total)
+		p.Quantity += quantity
+	default:
+		closed := min(abs(quantity), abs(p.Quantity))
+		direction := 1.0
+		if p.Quantity < 0 {
+			direction = -1
+		}
+
+		p.RealizedPnl += float64(closed) * (fill.Price - p.AveragePrice) * direction
+
+		flipped := abs(quantity) > abs(p.Quantity)
+		p.Quantity += quantity
+
+		switch {
+		case p.Quantity == 0:
+			p.AveragePrice = 0
+		case flipped:
+			p.AveragePrice = fill.Price
+		}
+	}
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+
+	return n
+}
@@ -81,7 +81,7 @@ func (g GetContractsRequest) queryParams() url.Values {
 	out := make(url.Values)
 	out.Add("exchange", string(g.Exchange))
 	out.Add("underlying_symbol", g.UnderlyingSymbol)
-	out.Add("expiry_date", g.ExpiryDate.Format(time.DateOnly))
+	out.Add("expiry_date", g.ExpiryDate.In(IST).Format(time.DateOnly))
 	return out
 }
 
@@ -105,9 +105,9 @@ type GetHistoricalCandlesRequest struct {
 	Segment Segment `json:"segment"`
 	// Groww symbol of the instrument for which historical data is required
 	GrowwSymbol string `json:"groww_symbol"`
-	// Start time from which data is required
+	// Start time from which data is required. Sent in IST irrespective of its location
 	StartTime time.Time `json:"start_time"`
-	// End time until which data is required
+	// End time until which data is required. Sent in IST irrespective of its location
 	EndTime time.Time `json:"end_time"`
 	// Interval for which data is required.
 	CandleInterval CandleInterval `json:"candle_interval"`
@@ -126,7 +126,7 @@ type Candle struct {
 
 func (c *Candle) UnmarshalJSON(bytes []byte) error {
 	asString := string(bytes)
-	var arr [][]byte
+	var arr []json.RawMessage
 
 	if err := json.Unmarshal(bytes, &arr); err != nil {
 		return fmt.Errorf("parse into array(%q): %w", asString, err)
@@ -167,6 +167,12 @@ func (c *Candle) UnmarshalJSON(bytes []byte) error {
 	return nil
 }
 
+// MarshalJSON encodes the candle the way Candle.UnmarshalJSON decodes it,
+// i.e. as [timestamp, open, high, low, close, volume, open interest]
+func (c Candle) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]any{c.Timestamp, c.Open, c.High, c.Low, c.Close, c.Volume, c.OpenInterest})
+}
+
 // HistoricalCandlesData represents the response of Client.GetHistoricalCandles
 //
 // https://groww.in/trade-api/docs/curl/backtesting#response-schema-2
@@ -188,8 +194,8 @@ func (g GetHistoricalCandlesRequest) queryParams() url.Values {
 	out.Add("exchange", string(g.Exchange))
 	out.Add("segment", string(g.Segment))
 	out.Add("groww_symbol", g.GrowwSymbol)
-	out.Add("start_time", g.StartTime.Format(time.DateTime))
-	out.Add("end_time", g.EndTime.Format(time.DateTime))
+	out.Add("start_time", g.StartTime.In(IST).Format(time.DateTime))
+	out.Add("end_time", g.EndTime.In(IST).Format(time.DateTime))
 	out.Add("candle_interval", string(g.CandleInterval))
 	return out
 }
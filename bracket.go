@@ -0,0 +1,843 @@
+package growwapi
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"slices"
+	"sync"
+	"time"
+)
+
+// BracketOrderRequest represents the request for BracketEngine.Place
+type BracketOrderRequest struct {
+	// Entry order. Its TransactionType decides the direction of the bracket.
+	// OrderReferenceId must be empty, it is derived from the id of the bracket.
+	Entry PlaceOrderRequest `json:"entry"`
+	// Limit price in rupees for the target order
+	TargetPrice float32 `json:"target_price"`
+	// Trigger price in rupees for the stop loss order
+	StopLossTriggerPrice float32 `json:"stop_loss_trigger_price"`
+	// [Optional] Limit price in rupees for the stop loss order. If not set, OrderTypeStopLossMarket is used
+	StopLossPrice float32 `json:"stop_loss_price,omitempty"`
+	// [Optional] Distance in rupees from the last traded price at which the stop loss trails. Trailing is disabled if not set
+	TrailingDistance float32 `json:"trailing_distance,omitempty"`
+	// [Optional] Minimum price movement of the instrument. Trailed prices are rounded to it
+	TickSize float32 `json:"tick_size,omitempty"`
+}
+
+// BracketState represents the lifecycle state of a BracketOrder
+type BracketState string
+
+const (
+	// BracketStatePendingEntry - Entry order is placed and nothing of it is filled yet
+	BracketStatePendingEntry BracketState = "PENDING_ENTRY"
+
+	// BracketStateOpen - Entry order is at least partially filled, target and stop loss orders are live for the
+	// filled quantity. Exits grow with the entry while the rest of it is still working.
+	BracketStateOpen BracketState = "OPEN"
+
+	// BracketStateClosed - Either target or stop loss got filled and the other one was cancelled
+	BracketStateClosed BracketState = "CLOSED"
+
+	// BracketStateCancelled - Entry order was cancelled or rejected without any fill, or the bracket was cancelled
+	BracketStateCancelled BracketState = "CANCELLED"
+
+	// BracketStateFailed - Exit orders could not be managed, the position needs manual attention
+	BracketStateFailed BracketState = "FAILED"
+)
+
+// IsFinal returns true if the BracketEngine no longer manages the bracket
+func (s BracketState) IsFinal() bool {
+	return s == BracketStateClosed || s == BracketStateCancelled || s == BracketStateFailed
+}
+
+// BracketOrder represents the state of a bracket managed by BracketEngine
+type BracketOrder struct {
+	// Id of the bracket generated by BracketEngine. Order reference ids of all legs are derived from it
+	Id string `json:"id"`
+	// Request the bracket was placed with
+	Request BracketOrderRequest `json:"request"`
+	// Current state of the bracket
+	State BracketState `json:"state"`
+	// Order id of the entry order
+	EntryOrderId string `json:"entry_order_id,omitempty"`
+	// Order id of the target order
+	TargetOrderId string `json:"target_order_id,omitempty"`
+	// Order id of the stop loss order
+	StopLossOrderId string `json:"stop_loss_order_id,omitempty"`
+	// Last known status of the entry order
+	EntryStatus OrderStatus `json:"entry_status,omitempty"`
+	// Filled quantity of the entry order
+	EntryFilledQuantity int `json:"entry_filled_quantity"`
+	// Filled quantity of the target order
+	TargetFilledQuantity int `json:"target_filled_quantity"`
+	// Filled quantity of the stop loss order
+	StopLossFilledQuantity int `json:"stop_loss_filled_quantity"`
+	// Current trigger price of the stop loss order. Differs from the request once trailing kicks in
+	StopLossTriggerPrice float32 `json:"stop_loss_trigger_price"`
+	// Current limit price of the stop loss order
+	StopLossPrice float32 `json:"stop_loss_price,omitempty"`
+	// Reason for the last state change
+	Remark string `json:"remark,omitempty"`
+	// Time of the last state change
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// OpenQuantity returns the quantity still held by the bracket
+func (b BracketOrder) OpenQuantity() int {
+	return b.EntryFilledQuantity - b.TargetFilledQuantity - b.StopLossFilledQuantity
+}
+
+func (b BracketOrder) entryReferenceId() string    { return b.Id + "-E" }
+func (b BracketOrder) targetReferenceId() string   { return b.Id + "-T" }
+func (b BracketOrder) stopLossReferenceId() string { return b.Id + "-S" }
+
+func (b BracketOrder) exitTransactionType() TransactionType {
+	if b.Request.Entry.TransactionType == TransactionTypeBuy {
+		return TransactionTypeSell
+	}
+
+	return TransactionTypeBuy
+}
+
+func (b BracketOrder) stopLossOrderType() OrderType {
+	if b.StopLossPrice == 0 {
+		return OrderTypeStopLossMarket
+	}
+
+	return OrderTypeStopLoss
+}
+
+// BracketStore persists the state of brackets so that BracketEngine can resume after a restart
+type BracketStore interface {
+	// SaveBracket creates or replaces the bracket with the same Id
+	SaveBracket(ctx context.Context, bracket BracketOrder) error
+	// LoadBrackets returns all the saved brackets
+	LoadBrackets(ctx context.Context) ([]BracketOrder, error)
+}
+
+// MemoryBracketStore is a BracketStore keeping brackets in memory. State is lost on restart
+type MemoryBracketStore struct {
+	mu       sync.Mutex
+	brackets map[string]BracketOrder
+}
+
+// SaveBracket implements BracketStore
+func (m *MemoryBracketStore) SaveBracket(_ context.Context, bracket BracketOrder) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.brackets == nil {
+		m.brackets = make(map[string]BracketOrder)
+	}
+
+	m.brackets[bracket.Id] = bracket
+	return nil
+}
+
+// LoadBrackets implements BracketStore
+func (m *MemoryBracketStore) LoadBrackets(_ context.Context) ([]BracketOrder, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	out := make([]BracketOrder, 0, len(m.brackets))
+	for _, b := range m.brackets {
+		out = append(out, b)
+	}
+
+	return out, nil
+}
+
+// FileBracketStore is a BracketStore keeping all brackets in a single json file.
+// The file is replaced atomically on every save.
+type FileBracketStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileBracketStore creates a FileBracketStore backed by the file at path
+func NewFileBracketStore(path string) *FileBracketStore {
+	return &FileBracketStore{path: path}
+}
+
+// SaveBracket implements BracketStore
+func (f *FileBracketStore) SaveBracket(_ context.Context, bracket BracketOrder) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	brackets, err := f.load()
+	if err != nil {
+		return err
+	}
+
+	brackets[bracket.Id] = bracket
+
+	msg, err := json.MarshalIndent(brackets, "", "  ")
+	if err != nil {
+		return fmt.Errorf("json.Marshal: %w", err)
+	}
+
+	if err := writeFileAtomic(f.path, msg); err != nil {
+		return fmt.Errorf("writeFileAtomic(%q): %w", f.path, err)
+	}
+
+	return nil
+}
+
+// LoadBrackets implements BracketStore
+func (f *FileBracketStore) LoadBrackets(_ context.Context) ([]BracketOrder, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	brackets, err := f.load()
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]BracketOrder, 0, len(brackets))
+	for _, b := range brackets {
+		out = append(out, b)
+	}
+
+	return out, nil
+}
+
+func (f *FileBracketStore) load() (map[string]BracketOrder, error) {
+	out := make(map[string]BracketOrder)
+
+	msg, err := os.ReadFile(f.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return out, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("os.ReadFile(%q): %w", f.path, err)
+	}
+
+	if err := json.Unmarshal(msg, &out); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%q): %w", f.path, err)
+	}
+
+	return out, nil
+}
+
+// BracketEngine emulates bracket orders on the client side.
+// Once the entry order is filled, even partially, it places a target LIMIT order and a stop loss order for the filled
+// quantity, and grows them as the rest of the entry fills. When one of them fills, the other one is cancelled (OCO),
+// along with what is left of the entry. The stop loss can optionally trail the last traded price,
+// see BracketEngine.OnLtp.
+//
+// Order updates are received through the OrderTracker, which must be running for the engine to make progress.
+type BracketEngine struct {
+	client  OrdersAPI
+	tracker *OrderTracker
+	store   BracketStore
+
+	// mu only guards the maps. Orders of a bracket are placed and changed under the lock of the bracket,
+	// so brackets do not wait on each other's API calls.
+	mu       sync.Mutex
+	brackets map[string]*managedBracket
+	// groww order id to bracket id
+	orders map[string]string
+}
+
+type managedBracket struct {
+	mu sync.Mutex
+	BracketOrder
+}
+
+// NewBracketEngine creates a new BracketEngine.
+// Use BracketEngine.Restore to resume the brackets saved in store.
+func NewBracketEngine(client OrdersAPI, tracker *OrderTracker, store BracketStore) *BracketEngine {
+	if store == nil {
+		store = &MemoryBracketStore{}
+	}
+
+	return &BracketEngine{
+		client:   client,
+		tracker:  tracker,
+		store:    store,
+		brackets: make(map[string]*managedBracket),
+		orders:   make(map[string]string),
+	}
+}
+
+// Restore loads the brackets from the store and resumes tracking the ones which are not final.
+// Updates missed while the process was down are applied on the first poll of the OrderTracker.
+func (e *BracketEngine) Restore(ctx context.Context) error {
+	brackets, err := e.store.LoadBrackets(ctx)
+	if err != nil {
+		return fmt.Errorf("store.LoadBrackets: %w", err)
+	}
+
+	for _, bracket := range brackets {
+		m := &managedBracket{BracketOrder: bracket}
+		e.mu.Lock()
+		e.brackets[bracket.Id] = m
+		e.mu.Unlock()
+
+		if err := e.restore(ctx, m); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (e *BracketEngine) restore(ctx context.Context, m *managedBracket) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.State.IsFinal() {
+		return nil
+	}
+
+	// the process might have stopped between placing the entry and saving its order id
+	if m.EntryOrderId == "" {
+		if err := e.resolveEntry(ctx, &m.BracketOrder); err != nil {
+			return err
+		}
+	}
+
+	if !m.State.IsFinal() {
+		e.track(&m.BracketOrder)
+	}
+
+	return nil
+}
+
+func (e *BracketEngine) resolveEntry(ctx context.Context, b *BracketOrder) error {
+	resp, err := e.client.GetOrderStatus(ctx, OrderStatusRequestWithOrderReferenceId{
+		OrderReferenceId: b.entryReferenceId(),
+		Segment:          b.Request.Entry.Segment,
+	})
+
+	if isNotFound(err) {
+		return e.transition(ctx, b, BracketStateCancelled, "entry order was never placed")
+	}
+
+	if err != nil {
+		return fmt.Errorf("GetOrderStatus(%s): %w", b.entryReferenceId(), err)
+	}
+
+	b.EntryOrderId = resp.GrowwOrderId
+	return e.save(ctx, b)
+}
+
+// Place places the entry order of a new bracket
+func (e *BracketEngine) Place(ctx context.Context, req BracketOrderRequest) (BracketOrder, error) {
+	if err := validateBracketOrderRequest(req); err != nil {
+		return BracketOrder{}, err
+	}
+
+	id, err := newBracketId()
+	if err != nil {
+		return BracketOrder{}, err
+	}
+
+	m := &managedBracket{BracketOrder: BracketOrder{
+		Id:                   id,
+		Request:              req,
+		State:                BracketStatePendingEntry,
+		StopLossTriggerPrice: req.StopLossTriggerPrice,
+		StopLossPrice:        req.StopLossPrice,
+	}}
+	bracket := &m.BracketOrder
+	bracket.Request.Entry.OrderReferenceId = bracket.entryReferenceId()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// save before placing, so that the entry can be resolved by reference id in case of a crash
+	if err := e.save(ctx, bracket); err != nil {
+		return BracketOrder{}, err
+	}
+
+	e.mu.Lock()
+	e.brackets[bracket.Id] = m
+	e.mu.Unlock()
+
+	resp, err := e.client.PlaceOrder(ctx, bracket.Request.Entry)
+	if err != nil {
+		_ = e.transition(ctx, bracket, BracketStateCancelled, fmt.Sprintf("entry order failed: %v", err))
+		return *bracket, fmt.Errorf("PlaceOrder(entry): %w", err)
+	}
+
+	bracket.EntryOrderId = resp.GrowwOrderId
+	if err := e.save(ctx, bracket); err != nil {
+		return *bracket, err
+	}
+
+	e.track(bracket)
+	return *bracket, nil
+}
+
+// Cancel cancels all live orders of the bracket. Quantity which is already filled is not squared off.
+func (e *BracketEngine) Cancel(ctx context.Context, id string) error {
+	m, ok := e.bracket(id)
+	if !ok {
+		return fmt.Errorf("bracket %q not found", id)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	bracket := &m.BracketOrder
+
+	if bracket.State.IsFinal() {
+		return nil
+	}
+
+	var errs []error
+	for _, orderId := range []string{bracket.EntryOrderId, bracket.TargetOrderId, bracket.StopLossOrderId} {
+		if err := e.cancelOrder(ctx, bracket, orderId); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	return e.transition(ctx, bracket, BracketStateCancelled, "cancelled by user")
+}
+
+// Bracket returns the current state of the bracket with given id
+func (e *BracketEngine) Bracket(id string) (BracketOrder, bool) {
+	m, ok := e.bracket(id)
+	if !ok {
+		return BracketOrder{}, false
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.BracketOrder, true
+}
+
+// Brackets returns the current state of all the brackets known to the engine
+func (e *BracketEngine) Brackets() []BracketOrder {
+	managed := e.managedBrackets()
+
+	out := make([]BracketOrder, 0, len(managed))
+	for _, m := range managed {
+		m.mu.Lock()
+		out = append(out, m.BracketOrder)
+		m.mu.Unlock()
+	}
+
+	slices.SortFunc(out, func(a, b BracketOrder) int { return a.UpdatedAt.Compare(b.UpdatedAt) })
+	return out
+}
+
+// OnLtp trails the stop loss of open brackets for the instrument using the last traded price.
+// For a long bracket, the stop loss trigger moves up to ltp - TrailingDistance and never moves down.
+// For a short bracket it moves down to ltp + TrailingDistance and never moves up.
+func (e *BracketEngine) OnLtp(ctx context.Context, exchange Exchange, tradingSymbol string, ltp float32) error {
+	var errs []error
+	for _, m := range e.managedBrackets() {
+		m.mu.Lock()
+		entry := m.Request.Entry
+		if m.State == BracketStateOpen && m.Request.TrailingDistance > 0 &&
+			entry.Exchange == exchange && entry.TradingSymbol == tradingSymbol {
+			if err := e.trail(ctx, &m.BracketOrder, ltp); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		m.mu.Unlock()
+	}
+
+	return errors.Join(errs...)
+}
+
+func (e *BracketEngine) trail(ctx context.Context, b *BracketOrder, ltp float32) error {
+	req := b.Request
+	long := req.Entry.TransactionType == TransactionTypeBuy
+
+	trigger := ltp - req.TrailingDistance
+	if !long {
+		trigger = ltp + req.TrailingDistance
+	}
+	trigger = roundToTick(trigger, req.TickSize)
+
+	if (long && trigger <= b.StopLossTriggerPrice) || (!long && trigger >= b.StopLossTriggerPrice) {
+		return nil
+	}
+
+	var price float32
+	if b.StopLossPrice != 0 {
+		price = roundToTick(trigger-(req.StopLossTriggerPrice-req.StopLossPrice), req.TickSize)
+	}
+
+	_, err := e.client.ModifyOrder(ctx, ModifyOrderRequest{
+		Quantity:     b.OpenQuantity(),
+		Price:        price,
+		TriggerPrice: trigger,
+		OrderType:    b.stopLossOrderType(),
+		Segment:      req.Entry.Segment,
+		GrowwOrderId: b.StopLossOrderId,
+	})
+	if err != nil {
+		return fmt.Errorf("ModifyOrder(stop loss %s): %w", b.StopLossOrderId, err)
+	}
+
+	b.StopLossTriggerPrice = trigger
+	b.StopLossPrice = price
+	b.Remark = fmt.Sprintf("stop loss trailed to %v", trigger)
+	b.UpdatedAt = time.Now()
+	return e.save(ctx, b)
+}
+
+func (e *BracketEngine) onOrderUpdate(ctx context.Context, update OrderUpdate) {
+	e.mu.Lock()
+	m, ok := e.brackets[e.orders[update.GrowwOrderId]]
+	e.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	bracket := &m.BracketOrder
+	if bracket.State.IsFinal() {
+		return
+	}
+
+	filled := update.FilledQuantity
+	if filled == 0 && update.Status.IsFilled() {
+		filled = e.orderQuantity(bracket, update.GrowwOrderId)
+	}
+
+	// errors are persisted in the bracket remark, there is no caller to return them to
+	switch update.GrowwOrderId {
+	case bracket.EntryOrderId:
+		_ = e.onEntryUpdate(ctx, bracket, update.Status, filled)
+	case bracket.TargetOrderId:
+		_ = e.onExitUpdate(ctx, bracket, update.Status, filled, true)
+	case bracket.StopLossOrderId:
+		_ = e.onExitUpdate(ctx, bracket, update.Status, filled, false)
+	}
+}
+
+// onEntryUpdate protects the filled quantity of the entry as soon as there is one: exits are placed on the first fill
+// and resized as the rest of the entry fills
+func (e *BracketEngine) onEntryUpdate(ctx context.Context, b *BracketOrder, status OrderStatus, filled int) error {
+	previous := b.EntryFilledQuantity
+	b.EntryFilledQuantity = filled
+	b.EntryStatus = status
+
+	switch {
+	case filled == 0 && status.IsTerminal():
+		return e.transition(ctx, b, BracketStateCancelled, fmt.Sprintf("entry order %s", status))
+	case filled == 0:
+		return e.save(ctx, b)
+	case b.TargetOrderId == "" || b.StopLossOrderId == "":
+		return e.placeExits(ctx, b)
+	case filled > previous:
+		return e.resizeExits(ctx, b)
+	default:
+		return e.save(ctx, b)
+	}
+}
+
+func (e *BracketEngine) placeExits(ctx context.Context, b *BracketOrder) error {
+	entry := b.Request.Entry
+	exit := PlaceOrderRequest{
+		TradingSymbol:   entry.TradingSymbol,
+		Quantity:        b.EntryFilledQuantity,
+		Validity:        entry.Validity,
+		Exchange:        entry.Exchange,
+		Segment:         entry.Segment,
+		Product:         entry.Product,
+		TransactionType: b.exitTransactionType(),
+	}
+
+	// order ids are saved as soon as a leg is placed, so that a restart does not place it again
+	if b.TargetOrderId == "" {
+		target := exit
+		target.OrderType = OrderTypeLimit
+		target.Price = b.Request.TargetPrice
+		target.OrderReferenceId = b.targetReferenceId()
+
+		orderId, err := e.placeExit(ctx, target)
+		if err != nil {
+			_ = e.transition(ctx, b, BracketStateFailed, fmt.Sprintf("target order failed: %v", err))
+			return fmt.Errorf("PlaceOrder(target): %w", err)
+		}
+
+		b.TargetOrderId = orderId
+		if err := e.save(ctx, b); err != nil {
+			return err
+		}
+	}
+
+	if b.StopLossOrderId == "" {
+		stopLoss := exit
+		stopLoss.OrderType = b.stopLossOrderType()
+		stopLoss.Price = b.StopLossPrice
+		stopLoss.TriggerPrice = b.StopLossTriggerPrice
+		stopLoss.OrderReferenceId = b.stopLossReferenceId()
+
+		orderId, err := e.placeExit(ctx, stopLoss)
+		if err != nil {
+			// a target without a stop loss would leave the position unprotected, so it does not stay working
+			remark := fmt.Sprintf("stop loss order failed: %v, target cancelled", err)
+			if cancelErr := e.cancelOrder(ctx, b, b.TargetOrderId); cancelErr != nil {
+				e.track(b)
+				remark = fmt.Sprintf("stop loss order failed: %v, target left working: %v", err, cancelErr)
+			}
+
+			_ = e.transition(ctx, b, BracketStateFailed, remark)
+			return fmt.Errorf("PlaceOrder(stop loss): %w", err)
+		}
+
+		b.StopLossOrderId = orderId
+	}
+
+	e.track(b)
+	return e.transition(ctx, b, BracketStateOpen, fmt.Sprintf("entry filled %d", b.EntryFilledQuantity))
+}
+
+// resizeExits grows both exit legs to the open quantity after more of the entry filled
+func (e *BracketEngine) resizeExits(ctx context.Context, b *BracketOrder) error {
+	if err := e.resizeOrder(ctx, b, b.TargetOrderId, true); err != nil {
+		_ = e.transition(ctx, b, BracketStateFailed, fmt.Sprintf("resize target: %v", err))
+		return err
+	}
+
+	if err := e.resizeOrder(ctx, b, b.StopLossOrderId, false); err != nil {
+		_ = e.transition(ctx, b, BracketStateFailed, fmt.Sprintf("resize stop loss: %v", err))
+		return err
+	}
+
+	b.Remark = fmt.Sprintf("entry filled %d", b.EntryFilledQuantity)
+	return e.save(ctx, b)
+}
+
+// placeExit places an exit leg, unless an order with its reference id already exists. That is the case when the
+// process stopped after placing the leg and before saving its order id.
+func (e *BracketEngine) placeExit(ctx context.Context, req PlaceOrderRequest) (string, error) {
+	status, err := e.client.GetOrderStatus(ctx, OrderStatusRequestWithOrderReferenceId{
+		OrderReferenceId: req.OrderReferenceId,
+		Segment:          req.Segment,
+	})
+	if err == nil {
+		return status.GrowwOrderId, nil
+	}
+
+	if !isNotFound(err) {
+		return "", fmt.Errorf("GetOrderStatus(%s): %w", req.OrderReferenceId, err)
+	}
+
+	resp, err := e.client.PlaceOrder(ctx, req)
+	if err != nil {
+		return "", err
+	}
+
+	return resp.GrowwOrderId, nil
+}
+
+func (e *BracketEngine) onExitUpdate(ctx context.Context, b *BracketOrder, status OrderStatus, filled int, isTarget bool) error {
+	name, otherName := "target", "stop loss"
+	otherId := b.StopLossOrderId
+	if isTarget {
+		b.TargetFilledQuantity = filled
+	} else {
+		name, otherName = otherName, name
+		otherId = b.TargetOrderId
+		b.StopLossFilledQuantity = filled
+	}
+
+	if b.OpenQuantity() <= 0 {
+		if err := e.cancelOrder(ctx, b, otherId); err != nil {
+			_ = e.transition(ctx, b, BracketStateFailed, fmt.Sprintf("%s filled, %v", name, err))
+			return err
+		}
+
+		// the rest of a partially filled entry would open a position without exits
+		if b.EntryFilledQuantity < b.Request.Entry.Quantity && !b.EntryStatus.IsTerminal() {
+			if err := e.cancelOrder(ctx, b, b.EntryOrderId); err != nil {
+				_ = e.transition(ctx, b, BracketStateFailed, fmt.Sprintf("%s filled, entry still working: %v", name, err))
+				return err
+			}
+		}
+
+		return e.transition(ctx, b, BracketStateClosed, fmt.Sprintf("%s filled", name))
+	}
+
+	if status.IsTerminal() {
+		return e.transition(ctx, b, BracketStateFailed, fmt.Sprintf("%s order %s with open quantity %d", name, status, b.OpenQuantity()))
+	}
+
+	if filled == 0 {
+		return e.save(ctx, b)
+	}
+
+	// partial fill, shrink the other leg to the open quantity
+	if err := e.resizeOrder(ctx, b, otherId, !isTarget); err != nil {
+		_ = e.transition(ctx, b, BracketStateFailed, fmt.Sprintf("resize %s: %v", otherName, err))
+		return err
+	}
+
+	return e.save(ctx, b)
+}
+
+// resizeOrder modifies an exit leg so that its unfilled quantity is the open quantity of the bracket
+func (e *BracketEngine) resizeOrder(ctx context.Context, b *BracketOrder, orderId string, isTarget bool) error {
+	req := ModifyOrderRequest{
+		Quantity:     b.OpenQuantity() + b.StopLossFilledQuantity,
+		Price:        b.StopLossPrice,
+		TriggerPrice: b.StopLossTriggerPrice,
+		OrderType:    b.stopLossOrderType(),
+		Segment:      b.Request.Entry.Segment,
+		GrowwOrderId: orderId,
+	}
+
+	if isTarget {
+		req.Quantity = b.OpenQuantity() + b.TargetFilledQuantity
+		req.Price = b.Request.TargetPrice
+		req.TriggerPrice = 0
+		req.OrderType = OrderTypeLimit
+	}
+
+	if _, err := e.client.ModifyOrder(ctx, req); err != nil {
+		return fmt.Errorf("ModifyOrder(%s): %w", orderId, err)
+	}
+
+	return nil
+}
+
+func (e *BracketEngine) cancelOrder(ctx context.Context, b *BracketOrder, orderId string) error {
+	if orderId == "" {
+		return nil
+	}
+
+	_, err := e.client.CancelOrder(ctx, CancelOrderRequest{Segment: b.Request.Entry.Segment, GrowwOrderId: orderId})
+	if err != nil {
+		return fmt.Errorf("CancelOrder(%s): %w", orderId, err)
+	}
+
+	return nil
+}
+
+func (e *BracketEngine) orderQuantity(b *BracketOrder, orderId string) int {
+	if orderId == b.EntryOrderId {
+		return b.Request.Entry.Quantity
+	}
+
+	return b.EntryFilledQuantity
+}
+
+func (e *BracketEngine) bracket(id string) (*managedBracket, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	m, ok := e.brackets[id]
+	return m, ok
+}
+
+func (e *BracketEngine) managedBrackets() []*managedBracket {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	out := make([]*managedBracket, 0, len(e.brackets))
+	for _, m := range e.brackets {
+		out = append(out, m)
+	}
+
+	return out
+}
+
+func (e *BracketEngine) track(b *BracketOrder) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	segment := b.Request.Entry.Segment
+
+	for _, orderId := range []string{b.EntryOrderId, b.TargetOrderId, b.StopLossOrderId} {
+		if orderId == "" {
+			continue
+		}
+
+		if _, ok := e.orders[orderId]; ok {
+			continue
+		}
+
+		e.orders[orderId] = b.Id
+		e.tracker.Track(orderId, segment, e.onOrderUpdate)
+	}
+}
+
+func (e *BracketEngine) transition(ctx context.Context, b *BracketOrder, state BracketState, remark string) error {
+	b.State = state
+	b.Remark = remark
+	return e.save(ctx, b)
+}
+
+func (e *BracketEngine) save(ctx context.Context, b *BracketOrder) error {
+	b.UpdatedAt = time.Now()
+	if err := e.store.SaveBracket(ctx, *b); err != nil {
+		return fmt.Errorf("store.SaveBracket(%s): %w", b.Id, err)
+	}
+
+	return nil
+}
+
+func validateBracketOrderRequest(req BracketOrderRequest) error {
+	if req.Entry.Quantity <= 0 {
+		return errors.New("entry quantity must be positive")
+	}
+
+	if req.Entry.OrderReferenceId != "" {
+		return errors.New("entry order reference id must be empty, it is derived from the bracket id")
+	}
+
+	if req.TargetPrice <= 0 || req.StopLossTriggerPrice <= 0 {
+		return errors.New("target price and stop loss trigger price are required")
+	}
+
+	switch req.Entry.TransactionType {
+	case TransactionTypeBuy:
+		if req.TargetPrice <= req.StopLossTriggerPrice {
+			return errors.New("target price must be above stop loss trigger price for a buy entry")
+		}
+	case TransactionTypeSell:
+		if req.TargetPrice >= req.StopLossTriggerPrice {
+			return errors.New("target price must be below stop loss trigger price for a sell entry")
+		}
+	default:
+		return fmt.Errorf("unknown transaction type %q", req.Entry.TransactionType)
+	}
+
+	return nil
+}
+
+// isNotFound reports whether err is the API error for an entity which does not exist
+func isNotFound(err error) bool {
+	var apiErr Error
+	return errors.As(err, &apiErr) && (apiErr.Code == ErrorCodeGA004 || apiErr.Code == ErrorCodeGA006)
+}
+
+func newBracketId() (string, error) {
+	b := make([]byte, 6)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("rand.Read: %w", err)
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+func roundToTick(price, tick float32) float32 {
+	if tick <= 0 {
+		return price
+	}
+
+	return float32(math.Round(float64(price/tick))) * tick
+}
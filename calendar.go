@@ -0,0 +1,389 @@
+package growwapi
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// SessionType represents a trading session of a day
+type SessionType string
+
+const (
+	// SessionTypePreOpen - Orders are collected and matched at a single equilibrium price
+	SessionTypePreOpen SessionType = "PRE_OPEN"
+
+	// SessionTypeNormal - Continuous trading
+	SessionTypeNormal SessionType = "NORMAL"
+
+	// SessionTypeClosing - Closing price of the day is calculated
+	SessionTypeClosing SessionType = "CLOSING"
+
+	// SessionTypePostClose - Orders are traded at the closing price
+	SessionTypePostClose SessionType = "POST_CLOSE"
+)
+
+// Session represents a trading session on a day, in IST
+type Session struct {
+	// Type of the session
+	Type SessionType
+	// Time the session starts at, inclusive
+	Start time.Time
+	// Time the session ends at, exclusive
+	End time.Time
+}
+
+// Contains reports whether t falls within the session
+func (s Session) Contains(t time.Time) bool {
+	return !t.Before(s.Start) && t.Before(s.End)
+}
+
+// sessionSpec is a session as a time of day
+type sessionSpec struct {
+	Type  SessionType `json:"type"`
+	Start clock       `json:"start"`
+	End   clock       `json:"end"`
+}
+
+// clock is a time of day formatted as 15:04
+type clock struct {
+	hour, minute int
+}
+
+func (c *clock) UnmarshalJSON(bytes []byte) error {
+	var asString string
+	if err := json.Unmarshal(bytes, &asString); err != nil {
+		return fmt.Errorf("json.Unmarshal(%q): %w", string(bytes), err)
+	}
+
+	parsed, err := time.Parse("15:04", asString)
+	if err != nil {
+		return fmt.Errorf("time.Parse(%q): %w", asString, err)
+	}
+
+	c.hour, c.minute = parsed.Hour(), parsed.Minute()
+	return nil
+}
+
+func (c clock) on(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, c.hour, c.minute, 0, 0, IST)
+}
+
+// regularSessions are the sessions of a regular trading day by segment
+var regularSessions = map[Segment][]sessionSpec{
+	SegmentCash: {
+		{SessionTypePreOpen, clock{9, 0}, clock{9, 15}},
+		{SessionTypeNormal, clock{9, 15}, clock{15, 30}},
+		{SessionTypeClosing, clock{15, 30}, clock{15, 40}},
+		{SessionTypePostClose, clock{15, 40}, clock{16, 0}},
+	},
+	SegmentFno: {
+		{SessionTypeNormal, clock{9, 15}, clock{15, 30}},
+	},
+}
+
+// marketCalendarData is the format of the data loaded by NewMarketCalendar.
+// Exchanges and segments can be omitted from an entry to apply it to all of them.
+type marketCalendarData struct {
+	Holidays []struct {
+		Date        string     `json:"date"`
+		Description string     `json:"description"`
+		Exchanges   []Exchange `json:"exchanges"`
+		Segments    []Segment  `json:"segments"`
+	} `json:"holidays"`
+	SpecialSessions []struct {
+		Date        string        `json:"date"`
+		Description string        `json:"description"`
+		Exchanges   []Exchange    `json:"exchanges"`
+		Segments    []Segment     `json:"segments"`
+		Sessions    []sessionSpec `json:"sessions"`
+	} `json:"special_sessions"`
+}
+
+type calendarKey struct {
+	exchange Exchange
+	segment  Segment
+	date     string
+}
+
+type specialSessions struct {
+	description string
+	sessions    []sessionSpec
+}
+
+// MarketCalendar knows the trading sessions of NSE and BSE per segment: the regular sessions of weekdays,
+// exchange holidays, and special sessions such as Muhurat trading which take place on otherwise closed days.
+//
+// All dates and times are in IST. Dates passed to MarketCalendar are converted to IST before taking their date.
+//
+// The calendar only knows the holidays and special sessions of the years in its data, see MarketCalendar.Covers.
+// Dates of other years are treated as regular: weekdays are trading days and weekends are not.
+type MarketCalendar struct {
+	holidays map[calendarKey]string
+	special  map[calendarKey]specialSessions
+	// first and last year with holidays or special sessions, zero if there are none
+	firstYear, lastYear int
+}
+
+//go:embed data/market_calendar.json
+var embeddedMarketCalendar []byte
+
+var defaultMarketCalendar = sync.OnceValues(func() (*MarketCalendar, error) {
+	return parseMarketCalendar(embeddedMarketCalendar)
+})
+
+// DefaultMarketCalendar returns the MarketCalendar with the holidays and special sessions shipped with this package,
+// which covers 2025 and 2026. Use NewMarketCalendar to load a more recent calendar.
+func DefaultMarketCalendar() *MarketCalendar {
+	calendar, err := defaultMarketCalendar()
+	if err != nil {
+		panic(fmt.Sprintf("embedded market calendar: %v", err))
+	}
+
+	return calendar
+}
+
+// NewMarketCalendar loads a MarketCalendar from JSON in the format of data/market_calendar.json
+func NewMarketCalendar(r io.Reader) (*MarketCalendar, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("io.ReadAll: %w", err)
+	}
+
+	return parseMarketCalendar(data)
+}
+
+func parseMarketCalendar(data []byte) (*MarketCalendar, error) {
+	var parsed marketCalendarData
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal: %w", err)
+	}
+
+	out := &MarketCalendar{
+		holidays: make(map[calendarKey]string),
+		special:  make(map[calendarKey]specialSessions),
+	}
+
+	for _, holiday := range parsed.Holidays {
+		if _, err := time.Parse(time.DateOnly, holiday.Date); err != nil {
+			return nil, fmt.Errorf("holiday %q: %w", holiday.Date, err)
+		}
+
+		for _, key := range calendarKeys(holiday.Date, holiday.Exchanges, holiday.Segments) {
+			out.holidays[key] = holiday.Description
+		}
+
+		out.addYear(holiday.Date)
+	}
+
+	for _, special := range parsed.SpecialSessions {
+		if _, err := time.Parse(time.DateOnly, special.Date); err != nil {
+			return nil, fmt.Errorf("special session %q: %w", special.Date, err)
+		}
+
+		for _, key := range calendarKeys(special.Date, special.Exchanges, special.Segments) {
+			out.special[key] = specialSessions{description: special.Description, sessions: special.Sessions}
+		}
+
+		out.addYear(special.Date)
+	}
+
+	return out, nil
+}
+
+// addYear extends the years covered by c to the year of date, which is formatted as time.DateOnly
+func (c *MarketCalendar) addYear(date string) {
+	year, _ := strconv.Atoi(date[:4])
+	if c.firstYear == 0 || year < c.firstYear {
+		c.firstYear = year
+	}
+
+	c.lastYear = max(c.lastYear, year)
+}
+
+func calendarKeys(date string, exchanges []Exchange, segments []Segment) []calendarKey {
+	if len(exchanges) == 0 {
+		exchanges = []Exchange{ExchangeNse, ExchangeBse}
+	}
+
+	if len(segments) == 0 {
+		segments = []Segment{SegmentCash, SegmentFno}
+	}
+
+	var out []calendarKey
+	for _, exchange := range exchanges {
+		for _, segment := range segments {
+			out = append(out, calendarKey{exchange, segment, date})
+		}
+	}
+
+	return out
+}
+
+// Covers reports whether the date of d is in a year the calendar has holidays or special sessions for.
+// For dates which are not covered, holidays and special sessions are unknown rather than absent.
+func (c *MarketCalendar) Covers(d time.Time) bool {
+	year := d.In(IST).Year()
+	return c.firstYear != 0 && year >= c.firstYear && year <= c.lastYear
+}
+
+// Holiday returns the description of the holiday on the date of d, if it is an exchange holiday.
+// Weekends are not reported as holidays.
+func (c *MarketCalendar) Holiday(exchange Exchange, segment Segment, d time.Time) (string, bool) {
+	description, ok := c.holidays[calendarKey{exchange, segment, d.In(IST).Format(time.DateOnly)}]
+	return description, ok
+}
+
+// Sessions returns the sessions on the date of d, ordered by time. Empty if the market is closed for the day.
+//
+// Special sessions replace the regular sessions of their day, limited to the session types the segment has.
+func (c *MarketCalendar) Sessions(exchange Exchange, segment Segment, d time.Time) []Session {
+	d = d.In(IST)
+	key := calendarKey{exchange, segment, d.Format(time.DateOnly)}
+	regular := regularSessions[segment]
+
+	specs := regular
+	if special, ok := c.special[key]; ok {
+		specs = nil
+		for _, spec := range special.sessions {
+			if slices.ContainsFunc(regular, func(s sessionSpec) bool { return s.Type == spec.Type }) {
+				specs = append(specs, spec)
+			}
+		}
+	} else if _, ok := c.holidays[key]; ok || d.Weekday() == time.Saturday || d.Weekday() == time.Sunday {
+		return nil
+	}
+
+	year, month, day := d.Date()
+	out := make([]Session, 0, len(specs))
+	for _, spec := range specs {
+		out = append(out, Session{Type: spec.Type, Start: spec.Start.on(year, month, day), End: spec.End.on(year, month, day)})
+	}
+
+	return out
+}
+
+// SessionAt returns the session t falls in
+func (c *MarketCalendar) SessionAt(exchange Exchange, segment Segment, t time.Time) (Session, bool) {
+	for _, session := range c.Sessions(exchange, segment, t) {
+		if session.Contains(t) {
+			return session, true
+		}
+	}
+
+	return Session{}, false
+}
+
+// IsTradingDay reports whether there is a normal session on the date of d
+func (c *MarketCalendar) IsTradingDay(exchange Exchange, segment Segment, d time.Time) bool {
+	_, ok := c.normalSession(exchange, segment, d)
+	return ok
+}
+
+// IsOpen reports whether t falls in a normal session, i.e. continuous trading is on
+func (c *MarketCalendar) IsOpen(exchange Exchange, segment Segment, t time.Time) bool {
+	session, ok := c.SessionAt(exchange, segment, t)
+	return ok && session.Type == SessionTypeNormal
+}
+
+// calendarSearchDays bounds the days NextOpen, NextClose, PreviousTradingDay and NextTradingDay look through,
+// so that a calendar closing the market for good doesn't make them loop forever
+const calendarSearchDays = 366
+
+// NextOpen returns the start of the first normal session starting at or after t.
+// If the market is open at t, that is the start of the next session rather than t.
+// False if there is no normal session within calendarSearchDays of t.
+func (c *MarketCalendar) NextOpen(exchange Exchange, segment Segment, t time.Time) (time.Time, bool) {
+	d := t.In(IST)
+	for range calendarSearchDays {
+		if session, ok := c.normalSession(exchange, segment, d); ok && !session.Start.Before(t) {
+			return session.Start, true
+		}
+
+		d = nextDate(d)
+	}
+
+	return time.Time{}, false
+}
+
+// NextClose returns the end of the normal session the market is open in at t, or of the next one if it is closed.
+// False if there is no normal session within calendarSearchDays of t.
+func (c *MarketCalendar) NextClose(exchange Exchange, segment Segment, t time.Time) (time.Time, bool) {
+	d := t.In(IST)
+	for range calendarSearchDays {
+		if session, ok := c.normalSession(exchange, segment, d); ok && session.End.After(t) {
+			return session.End, true
+		}
+
+		d = nextDate(d)
+	}
+
+	return time.Time{}, false
+}
+
+// PreviousTradingDay returns the last trading day before the date of d, as midnight IST.
+// False if there is no trading day within calendarSearchDays before d.
+func (c *MarketCalendar) PreviousTradingDay(exchange Exchange, segment Segment, d time.Time) (time.Time, bool) {
+	d = startOfDate(d)
+	for range calendarSearchDays {
+		d = d.AddDate(0, 0, -1)
+		if c.IsTradingDay(exchange, segment, d) {
+			return d, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// NextTradingDay returns the first trading day after the date of d, as midnight IST.
+// False if there is no trading day within calendarSearchDays after d.
+func (c *MarketCalendar) NextTradingDay(exchange Exchange, segment Segment, d time.Time) (time.Time, bool) {
+	for range calendarSearchDays {
+		d = nextDate(d)
+		if c.IsTradingDay(exchange, segment, d) {
+			return d, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// TradingDaysBetween returns the trading days from the date of from to the date of to, both inclusive, as midnight IST
+func (c *MarketCalendar) TradingDaysBetween(exchange Exchange, segment Segment, from, to time.Time) []time.Time {
+	var out []time.Time
+	last := startOfDate(to)
+
+	for d := startOfDate(from); !d.After(last); d = nextDate(d) {
+		if c.IsTradingDay(exchange, segment, d) {
+			out = append(out, d)
+		}
+	}
+
+	return out
+}
+
+func (c *MarketCalendar) normalSession(exchange Exchange, segment Segment, d time.Time) (Session, bool) {
+	for _, session := range c.Sessions(exchange, segment, d) {
+		if session.Type == SessionTypeNormal {
+			return session, true
+		}
+	}
+
+	return Session{}, false
+}
+
+// startOfDate returns midnight IST of the date of t
+func startOfDate(t time.Time) time.Time {
+	year, month, day := t.In(IST).Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, IST)
+}
+
+// nextDate returns midnight IST of the date after t
+func nextDate(t time.Time) time.Time {
+	return startOfDate(t).AddDate(0, 0, 1)
+}
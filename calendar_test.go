@@ -0,0 +1,100 @@
+package growwapi
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMarketCalendarNextOpen(t *testing.T) {
+	calendar := DefaultMarketCalendar()
+
+	tests := []struct {
+		name string
+		t    time.Time
+		want time.Time
+	}{
+		{
+			name: "before the open",
+			t:    time.Date(2025, time.April, 9, 8, 0, 0, 0, IST),
+			want: time.Date(2025, time.April, 9, 9, 15, 0, 0, IST),
+		},
+		{
+			name: "over a holiday",
+			t:    time.Date(2025, time.April, 9, 10, 0, 0, 0, IST),
+			want: time.Date(2025, time.April, 11, 9, 15, 0, 0, IST),
+		},
+		{
+			name: "Muhurat trading on a Sunday",
+			t:    time.Date(2026, time.November, 7, 10, 0, 0, 0, IST),
+			want: time.Date(2026, time.November, 8, 18, 0, 0, 0, IST),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := calendar.NextOpen(ExchangeNse, SegmentCash, tt.t)
+			if !ok || !got.Equal(tt.want) {
+				t.Errorf("NextOpen() = %v, %v, want %v", got, ok, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarketCalendarClosedForGood(t *testing.T) {
+	// every day for longer than the days searched for a trading day is a holiday
+	var holidays []string
+	last := time.Date(2026, time.January, 9, 0, 0, 0, 0, IST)
+	for d := time.Date(2025, time.January, 1, 0, 0, 0, 0, IST); !d.After(last); d = d.AddDate(0, 0, 1) {
+		holidays = append(holidays, `{"date": "`+d.Format(time.DateOnly)+`", "description": "Closed"}`)
+	}
+
+	calendar, err := NewMarketCalendar(strings.NewReader(`{"holidays": [` + strings.Join(holidays, ",") + `]}`))
+	if err != nil {
+		t.Fatalf("NewMarketCalendar() error = %v", err)
+	}
+
+	at := time.Date(2025, time.January, 1, 10, 0, 0, 0, IST)
+	if got, ok := calendar.NextOpen(ExchangeNse, SegmentCash, at); ok {
+		t.Errorf("NextOpen() = %v, want none", got)
+	}
+
+	if got, ok := calendar.NextClose(ExchangeNse, SegmentCash, at); ok {
+		t.Errorf("NextClose() = %v, want none", got)
+	}
+
+	if got, ok := calendar.NextTradingDay(ExchangeNse, SegmentCash, at); ok {
+		t.Errorf("NextTradingDay() = %v, want none", got)
+	}
+
+	if got, ok := calendar.PreviousTradingDay(ExchangeNse, SegmentCash, last); ok {
+		t.Errorf("PreviousTradingDay() = %v, want none", got)
+	}
+}
+
+func TestMarketCalendarCovers(t *testing.T) {
+	calendar := DefaultMarketCalendar()
+
+	tests := []struct {
+		d    time.Time
+		want bool
+	}{
+		{d: time.Date(2024, time.December, 31, 23, 0, 0, 0, IST), want: false},
+		// 2025-01-01 in IST
+		{d: time.Date(2024, time.December, 31, 20, 0, 0, 0, time.UTC), want: true},
+		{d: time.Date(2026, time.December, 31, 0, 0, 0, 0, IST), want: true},
+		{d: time.Date(2027, time.January, 1, 0, 0, 0, 0, IST), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.d.String(), func(t *testing.T) {
+			if got := calendar.Covers(tt.d); got != tt.want {
+				t.Errorf("Covers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if empty, _ := NewMarketCalendar(strings.NewReader(`{}`)); empty.Covers(time.Now()) {
+		t.Errorf("Covers() = true for a calendar without data")
+	}
+}
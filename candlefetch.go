@@ -0,0 +1,221 @@
+package growwapi
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"slices"
+	"sync"
+	"time"
+)
+
+// MaxRequestRange returns the longest StartTime to EndTime range Client.GetHistoricalCandles accepts for the interval
+//
+// https://groww.in/trade-api/docs/curl/backtesting#get-historical-candle-data
+func (i CandleInterval) MaxRequestRange() time.Duration {
+	const day = 24 * time.Hour
+
+	switch i {
+	case CandleInterval1Min, CandleInterval2Min, CandleInterval3Min, CandleInterval5Min:
+		return 30 * day
+	case CandleInterval10Min, CandleInterval15Min, CandleInterval30Min:
+		return 90 * day
+	case CandleInterval1Hour, CandleInterval4Hour, CandleInterval1Day, CandleInterval1Week, CandleInterval1Month:
+		return 180 * day
+	default:
+		return 30 * day
+	}
+}
+
+// FetchCandlesOptions represents the options for Client.FetchCandles and Client.StreamCandles
+type FetchCandlesOptions struct {
+	// [Optional] Maximum number of requests in flight. Defaults to 4
+	Concurrency int
+	// [Optional] Maximum number of requests started per second. Defaults to 5.
+	// Calls made through the same Client also share a limit of 10 requests per second, which this can only lower.
+	RequestsPerSecond float64
+}
+
+func (o FetchCandlesOptions) withDefaults() FetchCandlesOptions {
+	if o.Concurrency <= 0 {
+		o.Concurrency = 4
+	}
+
+	if o.RequestsPerSecond <= 0 {
+		o.RequestsPerSecond = 5
+	}
+
+	return o
+}
+
+// FetchCandles fetches the candles of req for any range, by splitting it in windows Client.GetHistoricalCandles
+// accepts (see CandleInterval.MaxRequestRange) and fetching them concurrently.
+// Candles present in two windows are returned once, and the candles are sorted by timestamp.
+func (c *Client) FetchCandles(ctx context.Context, req GetHistoricalCandlesRequest, opts FetchCandlesOptions) ([]Candle, error) {
+	return FetchCandles(ctx, c, req, opts)
+}
+
+// FetchCandles is Client.FetchCandles for any BacktestingAPI
+func FetchCandles(ctx context.Context, api BacktestingAPI, req GetHistoricalCandlesRequest, opts FetchCandlesOptions) ([]Candle, error) {
+	var out []Candle
+	for candle, err := range StreamCandles(ctx, api, req, opts) {
+		if err != nil {
+			return nil, err
+		}
+
+		out = append(out, candle)
+	}
+
+	return out, nil
+}
+
+// StreamCandles is Client.FetchCandles as an iterator. Windows are fetched concurrently ahead of the iteration,
+// at most FetchCandlesOptions.Concurrency of them, and candles are yielded in order as soon as their window is fetched.
+// Iteration stops at the first error.
+func (c *Client) StreamCandles(ctx context.Context, req GetHistoricalCandlesRequest, opts FetchCandlesOptions) iter.Seq2[Candle, error] {
+	return StreamCandles(ctx, c, req, opts)
+}
+
+type candleWindowResult struct {
+	candles []Candle
+	err     error
+}
+
+// sharedRateLimiter is implemented by APIs whose calls share a rate limit, such as Client
+type sharedRateLimiter interface {
+	rateLimiter() *rateLimiter
+}
+
+// StreamCandles is Client.StreamCandles for any BacktestingAPI. Requests rejected by the API for exceeding its rate
+// limit are retried with exponential backoff.
+func StreamCandles(ctx context.Context, api BacktestingAPI, req GetHistoricalCandlesRequest, opts FetchCandlesOptions) iter.Seq2[Candle, error] {
+	opts = opts.withDefaults()
+
+	return func(yield func(Candle, error) bool) {
+		windows := candleWindows(req)
+		if len(windows) == 0 {
+			return
+		}
+
+		ctx, cancel := context.WithCancel(ctx)
+		var wg sync.WaitGroup
+		defer func() {
+			cancel()
+			wg.Wait()
+		}()
+
+		results := make([]chan candleWindowResult, len(windows))
+		for i := range results {
+			results[i] = make(chan candleWindowResult, 1)
+		}
+
+		// ahead bounds how many windows are fetched before the iteration consumes them
+		ahead := make(chan struct{}, opts.Concurrency)
+		jobs := make(chan int)
+		// calls wait for their own limit and for the one shared with the other calls of api, if any
+		limiters := []*rateLimiter{newRateLimiter(opts.RequestsPerSecond)}
+		if shared, ok := api.(sharedRateLimiter); ok {
+			limiters = append(limiters, shared.rateLimiter())
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer close(jobs)
+
+			for i := range windows {
+				select {
+				case ahead <- struct{}{}:
+				case <-ctx.Done():
+					return
+				}
+
+				select {
+				case jobs <- i:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+
+		for range opts.Concurrency {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				for i := range jobs {
+					results[i] <- fetchCandleWindow(ctx, api, limiters, windows[i])
+				}
+			}()
+		}
+
+		var last time.Time
+		for i := range windows {
+			var result candleWindowResult
+			select {
+			case result = <-results[i]:
+			case <-ctx.Done():
+				yield(Candle{}, ctx.Err())
+				return
+			}
+			<-ahead
+
+			if result.err != nil {
+				yield(Candle{}, result.err)
+				return
+			}
+
+			for _, candle := range result.candles {
+				// windows share their boundaries
+				if !last.IsZero() && !candle.Timestamp.After(last) {
+					continue
+				}
+
+				last = candle.Timestamp.Time
+				if !yield(candle, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func fetchCandleWindow(ctx context.Context, api BacktestingAPI, limiters []*rateLimiter, req GetHistoricalCandlesRequest) candleWindowResult {
+	for _, limiter := range limiters {
+		if err := limiter.Wait(ctx); err != nil {
+			return candleWindowResult{err: err}
+		}
+	}
+
+	resp, err := retryRateLimited(ctx, func() (HistoricalCandlesData, error) {
+		return api.GetHistoricalCandles(ctx, req)
+	})
+	if err != nil {
+		return candleWindowResult{err: fmt.Errorf("GetHistoricalCandles(%s, %s): %w",
+			req.StartTime.In(IST).Format(time.DateTime), req.EndTime.In(IST).Format(time.DateTime), err)}
+	}
+
+	candles := resp.Candles
+	slices.SortStableFunc(candles, func(a, b Candle) int { return a.Timestamp.Compare(b.Timestamp.Time) })
+	return candleWindowResult{candles: candles}
+}
+
+// candleWindows splits the range of req in consecutive windows of at most CandleInterval.MaxRequestRange
+func candleWindows(req GetHistoricalCandlesRequest) []GetHistoricalCandlesRequest {
+	maxRange := req.CandleInterval.MaxRequestRange()
+
+	var out []GetHistoricalCandlesRequest
+	for start := req.StartTime; start.Before(req.EndTime); {
+		end := start.Add(maxRange)
+		if end.After(req.EndTime) {
+			end = req.EndTime
+		}
+
+		window := req
+		window.StartTime, window.EndTime = start, end
+		out = append(out, window)
+		start = end
+	}
+
+	return out
+}
@@ -0,0 +1,444 @@
+package growwapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+)
+
+// TimeRange represents the closed range of time from Start to End
+type TimeRange struct {
+	Start time.Time
+	End   time.Time
+}
+
+// CandleStore persists candle series on disk, keyed by groww symbol and CandleInterval, and remembers which time
+// ranges of every series were fetched. Candles are only fetched with Client.GetHistoricalCandles for the parts of a
+// requested range which were not fetched before.
+//
+// Every series is stored in a single file in dir, which is rewritten atomically when the series grows.
+// Series are loaded in memory when first used, and range queries are served from memory.
+type CandleStore struct {
+	api  BacktestingAPI
+	dir  string
+	opts FetchCandlesOptions
+
+	mu     sync.Mutex
+	series map[candleSeriesKey]*candleSeries
+}
+
+type candleSeriesKey struct {
+	growwSymbol string
+	interval    CandleInterval
+}
+
+type candleSeries struct {
+	mu      sync.Mutex
+	loaded  bool
+	covered []TimeRange
+	candles []Candle
+}
+
+// NewCandleStore creates a new CandleStore storing series in dir, and fetching missing candles from api with
+// FetchCandles using opts
+func NewCandleStore(api BacktestingAPI, dir string, opts FetchCandlesOptions) *CandleStore {
+	return &CandleStore{
+		api:    api,
+		dir:    dir,
+		opts:   opts,
+		series: make(map[candleSeriesKey]*candleSeries),
+	}
+}
+
+// Candles returns the candles of req, fetching the parts of its range which are not stored yet.
+// Exchange and Segment of req are only used to fetch candles, the series is identified by GrowwSymbol and CandleInterval.
+func (s *CandleStore) Candles(ctx context.Context, req GetHistoricalCandlesRequest) ([]Candle, error) {
+	series, err := s.load(req.GrowwSymbol, req.CandleInterval)
+	if err != nil {
+		return nil, err
+	}
+
+	series.mu.Lock()
+	defer series.mu.Unlock()
+
+	if err := s.sync(ctx, series, req); err != nil {
+		return nil, err
+	}
+
+	return series.between(req.StartTime, req.EndTime), nil
+}
+
+// Sync fetches and stores the parts of the range of req which are not stored yet
+func (s *CandleStore) Sync(ctx context.Context, req GetHistoricalCandlesRequest) error {
+	series, err := s.load(req.GrowwSymbol, req.CandleInterval)
+	if err != nil {
+		return err
+	}
+
+	series.mu.Lock()
+	defer series.mu.Unlock()
+
+	return s.sync(ctx, series, req)
+}
+
+// Cached returns the stored candles of the series from start to end, both inclusive, without fetching anything
+func (s *CandleStore) Cached(growwSymbol string, interval CandleInterval, start, end time.Time) ([]Candle, error) {
+	series, err := s.load(growwSymbol, interval)
+	if err != nil {
+		return nil, err
+	}
+
+	series.mu.Lock()
+	defer series.mu.Unlock()
+
+	return series.between(start, end), nil
+}
+
+// Coverage returns the time ranges of the series which were fetched, in ascending order
+func (s *CandleStore) Coverage(growwSymbol string, interval CandleInterval) ([]TimeRange, error) {
+	series, err := s.load(growwSymbol, interval)
+	if err != nil {
+		return nil, err
+	}
+
+	series.mu.Lock()
+	defer series.mu.Unlock()
+
+	return slices.Clone(series.covered), nil
+}
+
+// Gaps returns the parts of the range from start to end which were not fetched yet
+func (s *CandleStore) Gaps(growwSymbol string, interval CandleInterval, start, end time.Time) ([]TimeRange, error) {
+	series, err := s.load(growwSymbol, interval)
+	if err != nil {
+		return nil, err
+	}
+
+	series.mu.Lock()
+	defer series.mu.Unlock()
+
+	return rangeGaps(series.covered, TimeRange{start, end}), nil
+}
+
+func (s *CandleStore) load(growwSymbol string, interval CandleInterval) (*candleSeries, error) {
+	s.mu.Lock()
+	key := candleSeriesKey{growwSymbol, interval}
+	series, ok := s.series[key]
+	if !ok {
+		series = &candleSeries{}
+		s.series[key] = series
+	}
+	s.mu.Unlock()
+
+	series.mu.Lock()
+	defer series.mu.Unlock()
+
+	if series.loaded {
+		return series, nil
+	}
+
+	data, err := os.ReadFile(s.path(key))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("os.ReadFile: %w", err)
+	}
+
+	if err == nil {
+		if series.covered, series.candles, err = decodeCandleSeries(data); err != nil {
+			return nil, fmt.Errorf("%s: %w", s.path(key), err)
+		}
+	}
+
+	series.loaded = true
+	return series, nil
+}
+
+func (s *CandleStore) sync(ctx context.Context, series *candleSeries, req GetHistoricalCandlesRequest) error {
+	gaps := rangeGaps(series.covered, TimeRange{req.StartTime, req.EndTime})
+	if len(gaps) == 0 {
+		return nil
+	}
+
+	// candles of the current day are still forming, so today is never considered covered
+	today := startOfDate(time.Now())
+
+	candles := series.candles
+	covered := series.covered
+	for _, gap := range gaps {
+		window := req
+		window.StartTime, window.EndTime = gap.Start, gap.End
+
+		fetched, err := FetchCandles(ctx, s.api, window, s.opts)
+		if err != nil {
+			return err
+		}
+
+		candles = mergeCandles(candles, fetched)
+		if gap.End.After(today) {
+			gap.End = today
+		}
+
+		if gap.End.After(gap.Start) {
+			covered = addRange(covered, gap)
+		}
+	}
+
+	data := encodeCandleSeries(covered, candles)
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return fmt.Errorf("os.MkdirAll: %w", err)
+	}
+
+	if err := writeFileAtomic(s.path(candleSeriesKey{req.GrowwSymbol, req.CandleInterval}), data); err != nil {
+		return err
+	}
+
+	series.candles, series.covered = candles, covered
+	return nil
+}
+
+func (s *CandleStore) path(key candleSeriesKey) string {
+	return filepath.Join(s.dir, fmt.Sprintf("%s_%s.candles", url.PathEscape(key.growwSymbol), key.interval))
+}
+
+// between returns the candles from start to end, both inclusive
+func (series *candleSeries) between(start, end time.Time) []Candle {
+	compare := func(c Candle, t time.Time) int { return c.Timestamp.Compare(t) }
+
+	from, _ := slices.BinarySearchFunc(series.candles, start, compare)
+	to, found := slices.BinarySearchFunc(series.candles, end, compare)
+	if found {
+		to++
+	}
+
+	if from >= to {
+		return nil
+	}
+
+	return slices.Clone(series.candles[from:to])
+}
+
+// mergeCandles merges two sorted series, preferring the candles of newer for the same timestamp
+func mergeCandles(older, newer []Candle) []Candle {
+	out := make([]Candle, 0, len(older)+len(newer))
+	i, j := 0, 0
+
+	for i < len(older) || j < len(newer) {
+		switch {
+		case j == len(newer) || (i < len(older) && older[i].Timestamp.Before(newer[j].Timestamp.Time)):
+			out = append(out, older[i])
+			i++
+		case i == len(older) || newer[j].Timestamp.Before(older[i].Timestamp.Time):
+			out = append(out, newer[j])
+			j++
+		default:
+			out = append(out, newer[j])
+			i++
+			j++
+		}
+	}
+
+	return out
+}
+
+// addRange adds r to the sorted, non overlapping ranges, merging the ones it overlaps or touches
+func addRange(ranges []TimeRange, r TimeRange) []TimeRange {
+	var out []TimeRange
+	for _, existing := range ranges {
+		if existing.End.Before(r.Start) || existing.Start.After(r.End) {
+			out = append(out, existing)
+			continue
+		}
+
+		r.Start = minTime(r.Start, existing.Start)
+		r.End = maxTime(r.End, existing.End)
+	}
+
+	out = append(out, r)
+	slices.SortFunc(out, func(a, b TimeRange) int { return a.Start.Compare(b.Start) })
+	return out
+}
+
+// rangeGaps returns the parts of r not covered by the sorted, non overlapping ranges
+func rangeGaps(ranges []TimeRange, r TimeRange) []TimeRange {
+	var out []TimeRange
+	start := r.Start
+
+	for _, covered := range ranges {
+		if covered.End.Before(start) {
+			continue
+		}
+
+		if covered.Start.After(r.End) {
+			break
+		}
+
+		if covered.Start.After(start) {
+			out = append(out, TimeRange{start, covered.Start})
+		}
+
+		start = maxTime(start, covered.End)
+	}
+
+	if start.Before(r.End) {
+		out = append(out, TimeRange{start, r.End})
+	}
+
+	return out
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+
+	return b
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+
+	return b
+}
+
+// Candle series files are laid out as
+//
+//	magic "GWCANDLE" | version uint32 | range count uint32 | ranges | candle count uint32 | candles
+//
+// where a range is its start and end as unix seconds in int64, and a candle is its timestamp as unix seconds in int64
+// followed by open, high, low, close, volume and open interest as float32. A missing open interest is stored as NaN.
+// All numbers are little endian.
+const (
+	candleSeriesMagic   = "GWCANDLE"
+	candleSeriesVersion = 1
+)
+
+type candleRecord struct {
+	Timestamp                                    int64
+	Open, High, Low, Close, Volume, OpenInterest float32
+}
+
+func encodeCandleSeries(covered []TimeRange, candles []Candle) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(candleSeriesMagic)
+
+	le := binary.LittleEndian
+	buf.Write(le.AppendUint32(nil, candleSeriesVersion))
+
+	buf.Write(le.AppendUint32(nil, uint32(len(covered))))
+	for _, r := range covered {
+		buf.Write(le.AppendUint64(nil, uint64(r.Start.Unix())))
+		buf.Write(le.AppendUint64(nil, uint64(r.End.Unix())))
+	}
+
+	buf.Write(le.AppendUint32(nil, uint32(len(candles))))
+	for _, c := range candles {
+		record := candleRecord{
+			Timestamp:    c.Timestamp.Unix(),
+			Open:         c.Open,
+			High:         c.High,
+			Low:          c.Low,
+			Close:        c.Close,
+			Volume:       c.Volume,
+			OpenInterest: float32(math.NaN()),
+		}
+
+		if c.OpenInterest != nil {
+			record.OpenInterest = *c.OpenInterest
+		}
+
+		// writing to a bytes.Buffer does not fail
+		_ = binary.Write(&buf, le, record)
+	}
+
+	return buf.Bytes()
+}
+
+// checkRecordCount returns an error if r has less than count records of recordSize bytes left
+func checkRecordCount(r *bytes.Reader, count uint32, recordSize int) error {
+	if int64(count)*int64(recordSize) > int64(r.Len()) {
+		return fmt.Errorf("%d records of %d bytes exceed the %d bytes left", count, recordSize, r.Len())
+	}
+
+	return nil
+}
+
+func decodeCandleSeries(data []byte) ([]TimeRange, []Candle, error) {
+	r := bytes.NewReader(data)
+	le := binary.LittleEndian
+
+	magic := make([]byte, len(candleSeriesMagic))
+	if _, err := io.ReadFull(r, magic); err != nil || string(magic) != candleSeriesMagic {
+		return nil, nil, fmt.Errorf("not a candle series file")
+	}
+
+	var version, rangeCount uint32
+	if err := binary.Read(r, le, &version); err != nil {
+		return nil, nil, fmt.Errorf("version: %w", err)
+	}
+
+	if version != candleSeriesVersion {
+		return nil, nil, fmt.Errorf("unsupported version %d", version)
+	}
+
+	if err := binary.Read(r, le, &rangeCount); err != nil {
+		return nil, nil, fmt.Errorf("range count: %w", err)
+	}
+
+	// counts are checked against the remaining data before allocating, so a corrupt count can't exhaust memory
+	if err := checkRecordCount(r, rangeCount, binary.Size([2]int64{})); err != nil {
+		return nil, nil, fmt.Errorf("ranges: %w", err)
+	}
+
+	rawRanges := make([][2]int64, rangeCount)
+	if err := binary.Read(r, le, rawRanges); err != nil {
+		return nil, nil, fmt.Errorf("ranges: %w", err)
+	}
+
+	covered := make([]TimeRange, rangeCount)
+	for i, raw := range rawRanges {
+		covered[i] = TimeRange{time.Unix(raw[0], 0).In(IST), time.Unix(raw[1], 0).In(IST)}
+	}
+
+	var candleCount uint32
+	if err := binary.Read(r, le, &candleCount); err != nil {
+		return nil, nil, fmt.Errorf("candle count: %w", err)
+	}
+
+	if err := checkRecordCount(r, candleCount, binary.Size(candleRecord{})); err != nil {
+		return nil, nil, fmt.Errorf("candles: %w", err)
+	}
+
+	records := make([]candleRecord, candleCount)
+	if err := binary.Read(r, le, records); err != nil {
+		return nil, nil, fmt.Errorf("candles: %w", err)
+	}
+
+	candles := make([]Candle, candleCount)
+	for i, record := range records {
+		candles[i] = Candle{
+			Timestamp: Time{time.Unix(record.Timestamp, 0).In(IST)},
+			Ohlcv: Ohlcv{
+				Ohlc:   Ohlc{Open: record.Open, High: record.High, Low: record.Low, Close: record.Close},
+				Volume: record.Volume,
+			},
+		}
+
+		if !math.IsNaN(float64(record.OpenInterest)) {
+			oi := record.OpenInterest
+			candles[i].OpenInterest = &oi
+		}
+	}
+
+	return covered, candles, nil
+}
@@ -7,21 +7,38 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"sync"
 )
 
 // Client to access groww apis
 type Client struct {
 	accessToken string
 	httpClient  *http.Client
+
+	// limiter is shared by all the calls made through the client which limit their rate, such as Client.StreamCandles
+	limiterOnce sync.Once
+	limiter     *rateLimiter
 }
 
+// clientRequestsPerSecond is the rate all the calls limiting their rate share per Client
+const clientRequestsPerSecond = 10
+
 // NewClient creates a new Client
-func NewClient(accessToken string, httpClient *http.Client) Client {
+func NewClient(accessToken string, httpClient *http.Client) *Client {
 	if httpClient == nil {
 		httpClient = http.DefaultClient
 	}
 
-	return Client{accessToken, httpClient}
+	return &Client{accessToken: accessToken, httpClient: httpClient}
+}
+
+// rateLimiter returns the rateLimiter of the client allowing clientRequestsPerSecond calls every second
+func (c *Client) rateLimiter() *rateLimiter {
+	c.limiterOnce.Do(func() {
+		c.limiter = newRateLimiter(clientRequestsPerSecond)
+	})
+
+	return c.limiter
 }
 
 // ErrorCode are codes returned by GROWW APIs
@@ -67,6 +84,8 @@ type Error struct {
 	Code     ErrorCode `json:"code"`
 	Message  string    `json:"message"`
 	Metadata any       `json:"metadata"`
+	// HTTP status code of the response, e.g. http.StatusTooManyRequests when rate limited
+	StatusCode int `json:"-"`
 }
 
 type apiResponse[T any] struct {
@@ -171,8 +190,10 @@ func doRequest[T any](ctx context.Context, c *Client, req *http.Request) (out T,
 	default:
 		var e errorResponse
 		if err := json.NewDecoder(resp.Body).Decode(&e); err != nil {
-			return out, fmt.Errorf("json.NewDecoder(success_response): %w", err)
+			// rate limiting and gateway errors don't come with a json body
+			return out, Error{Message: http.StatusText(resp.StatusCode), StatusCode: resp.StatusCode}
 		}
+		e.Error.StatusCode = resp.StatusCode
 		return out, e.Error
 	}
 }
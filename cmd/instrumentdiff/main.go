@@ -0,0 +1,106 @@
+// Command instrumentdiff compares two snapshots of the instrument master and prints the changes between them.
+//
+// Usage:
+//
+//	instrumentdiff [-kinds LOT_SIZE,TICK_SIZE] [-segment FNO] old.csv [new.csv]
+//
+// If new.csv is omitted, the current instrument master is downloaded.
+// The exit code is 1 if there are any changes, 0 otherwise.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/rctrj/growwapi-go"
+)
+
+func main() {
+	kinds := flag.String("kinds", "", "comma separated change kinds to report, e.g. LOT_SIZE,RENAMED. Empty reports all")
+	segment := flag.String("segment", "", "segment to report, e.g. CASH or FNO. Empty reports all")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: %s [flags] old.csv [new.csv]\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() < 1 || flag.NArg() > 2 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	before, err := readInstruments(flag.Arg(0))
+	if err != nil {
+		fatal(err)
+	}
+
+	var after []growwapi.Instrument
+	if flag.NArg() == 2 {
+		after, err = readInstruments(flag.Arg(1))
+	} else {
+		after, err = growwapi.Instruments(context.Background(), nil)
+	}
+	if err != nil {
+		fatal(err)
+	}
+
+	var wantKinds []growwapi.InstrumentChangeKind
+	for kind := range strings.SplitSeq(*kinds, ",") {
+		if kind = strings.TrimSpace(kind); kind != "" {
+			wantKinds = append(wantKinds, growwapi.InstrumentChangeKind(strings.ToUpper(kind)))
+		}
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "KIND\tEXCHANGE\tSEGMENT\tTRADING SYMBOL\tTOKEN\tBEFORE\tAFTER")
+
+	changed := false
+	for _, change := range growwapi.DiffInstruments(before, after) {
+		instrument := change.Instrument()
+		if len(wantKinds) > 0 && !slices.Contains(wantKinds, change.Kind) {
+			continue
+		}
+
+		if *segment != "" && !strings.EqualFold(string(instrument.Segment), *segment) {
+			continue
+		}
+
+		changed = true
+		from, to := change.Values()
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", change.Kind, instrument.Exchange, instrument.Segment,
+			instrument.TradingSymbol, instrument.ExchangeToken, from, to)
+	}
+
+	if err := w.Flush(); err != nil {
+		fatal(err)
+	}
+
+	if changed {
+		os.Exit(1)
+	}
+}
+
+func readInstruments(path string) ([]growwapi.Instrument, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	instruments, err := growwapi.ParseInstruments(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return instruments, nil
+}
+
+func fatal(err error) {
+	fmt.Fprintln(os.Stderr, "instrumentdiff:", err)
+	os.Exit(2)
+}
@@ -0,0 +1,225 @@
+package growwapi
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+)
+
+// Contract identifies a tradable instrument and produces the representation each API expects for it:
+// exchange and trading symbol for orders and quotes, exchange symbol for ltp and ohlc, groww symbol for candles and
+// underlying with expiry for greeks.
+type Contract struct {
+	// The exchange where the instrument is traded
+	Exchange Exchange
+	// Segment of the instrument such as CASH, FNO etc.
+	Segment Segment
+	// Trading symbol of the instrument as defined by the exchange
+	TradingSymbol string
+	// Symbol used by Groww to identify the instrument
+	GrowwSymbol string
+	// Trading symbol of the instrument for equities and indices, or of the underlying for derivatives
+	Underlying string
+	// The type of the instrument
+	InstrumentType InstrumentType
+	// Expiry date of derivatives, in IST. Zero for equities and indices
+	Expiry time.Time
+	// Strike price of options. Zero for others
+	StrikePrice float32
+	// Option type of options. Empty for others
+	OptionType OptionType
+}
+
+// ContractFromInstrument creates a Contract from an Instrument of the instrument master.
+// Symbols are taken as they are from the instrument, which makes it the most reliable way to create a Contract.
+func ContractFromInstrument(instrument Instrument) Contract {
+	out := NewContract(instrument.SymbolParts())
+	out.Segment = instrument.Segment
+	out.TradingSymbol = instrument.TradingSymbol
+
+	if instrument.GrowwSymbol != "" {
+		out.GrowwSymbol = instrument.GrowwSymbol
+	}
+
+	return out
+}
+
+// NewContract creates a Contract from the parts of its symbol. Derivatives are put in SegmentFno and everything else
+// in SegmentCash, and the symbols are generated as described by GrowwSymbolParts.TradingSymbol and
+// GrowwSymbolParts.GrowwSymbol.
+func NewContract(parts GrowwSymbolParts) Contract {
+	segment := SegmentCash
+	if !parts.Expiry.IsZero() {
+		segment = SegmentFno
+	}
+
+	return Contract{
+		Exchange:       parts.Exchange,
+		Segment:        segment,
+		TradingSymbol:  parts.TradingSymbol(),
+		GrowwSymbol:    parts.GrowwSymbol(),
+		Underlying:     parts.Underlying,
+		InstrumentType: parts.InstrumentType,
+		Expiry:         parts.Expiry,
+		StrikePrice:    parts.StrikePrice,
+		OptionType:     parts.OptionType,
+	}
+}
+
+// ContractFromGrowwSymbol creates a Contract from a groww symbol such as NSE-NIFTY-24Apr25-24100-PE
+func ContractFromGrowwSymbol(growwSymbol string) (Contract, error) {
+	parts, err := ParseGrowwSymbol(growwSymbol)
+	if err != nil {
+		return Contract{}, err
+	}
+
+	out := NewContract(parts)
+	out.GrowwSymbol = growwSymbol
+	return out, nil
+}
+
+// ContractFromExchangeSymbol creates a Contract from an exchange symbol such as NSE_NIFTY25APR24100PE.
+// See ParseTradingSymbol for the caveats of parsing trading symbols.
+func ContractFromExchangeSymbol(exchangeSymbol string) (Contract, error) {
+	parts, err := ParseExchangeSymbol(exchangeSymbol)
+	if err != nil {
+		return Contract{}, err
+	}
+
+	out := NewContract(parts)
+	out.TradingSymbol = exchangeSymbol[len(parts.Exchange)+1:]
+	return out, nil
+}
+
+// Parts returns the parts of the symbol of the contract
+func (c Contract) Parts() GrowwSymbolParts {
+	return GrowwSymbolParts{
+		Exchange:       c.Exchange,
+		Underlying:     c.Underlying,
+		InstrumentType: c.InstrumentType,
+		Expiry:         c.Expiry,
+		StrikePrice:    c.StrikePrice,
+		OptionType:     c.OptionType,
+	}
+}
+
+// ExchangeSymbol returns the exchange symbol of the contract, e.g. NSE_RELIANCE
+func (c Contract) ExchangeSymbol() string {
+	return fmt.Sprintf("%s_%s", c.Exchange, c.TradingSymbol)
+}
+
+func (c Contract) String() string {
+	return c.ExchangeSymbol()
+}
+
+// QuoteRequest returns the request for Client.GetQuote
+func (c Contract) QuoteRequest() QuoteRequest {
+	return QuoteRequest{Exchange: c.Exchange, Segment: c.Segment, TradingSymbol: c.TradingSymbol}
+}
+
+// HistoricalCandlesRequest returns the request for Client.GetHistoricalCandles
+func (c Contract) HistoricalCandlesRequest(start, end time.Time, interval CandleInterval) GetHistoricalCandlesRequest {
+	return GetHistoricalCandlesRequest{
+		Exchange:       c.Exchange,
+		Segment:        c.Segment,
+		GrowwSymbol:    c.GrowwSymbol,
+		StartTime:      start,
+		EndTime:        end,
+		CandleInterval: interval,
+	}
+}
+
+// GreeksRequest returns the request for Client.GetGreeks
+func (c Contract) GreeksRequest() GetGreeksRequest {
+	return GetGreeksRequest{
+		Exchange:      string(c.Exchange),
+		Underlying:    c.Underlying,
+		TradingSymbol: c.TradingSymbol,
+		Expiry:        c.Expiry,
+	}
+}
+
+// PlaceOrderRequest sets the TradingSymbol, Exchange and Segment of req to the ones of the contract
+func (c Contract) PlaceOrderRequest(req PlaceOrderRequest) PlaceOrderRequest {
+	req.TradingSymbol = c.TradingSymbol
+	req.Exchange = c.Exchange
+	req.Segment = c.Segment
+	return req
+}
+
+// GetQuoteFor is Client.GetQuote for a Contract
+func (c *Client) GetQuoteFor(ctx context.Context, contract Contract) (Quote, error) {
+	return c.GetQuote(ctx, contract.QuoteRequest())
+}
+
+// maxLiveDataSymbols is the maximum number of exchange symbols supported by Client.GetLtp and Client.GetOhlc per call
+const maxLiveDataSymbols = 50
+
+// GetLtpFor is Client.GetLtp for contracts, which may belong to different segments and be more than a single call supports.
+// The returned Ltp is keyed by Contract.ExchangeSymbol.
+func (c *Client) GetLtpFor(ctx context.Context, contracts ...Contract) (Ltp, error) {
+	out := make(Ltp, len(contracts))
+
+	for segment, symbols := range exchangeSymbolsBySegment(contracts) {
+		for batch := range slices.Chunk(symbols, maxLiveDataSymbols) {
+			ltp, err := c.GetLtp(ctx, LtpRequest{Segment: segment, ExchangeSymbols: batch})
+			if err != nil {
+				return nil, fmt.Errorf("GetLtp(%s): %w", segment, err)
+			}
+
+			maps.Copy(out, ltp)
+		}
+	}
+
+	return out, nil
+}
+
+// GetOhlcFor is Client.GetOhlc for contracts, which may belong to different segments and be more than a single call supports.
+// The returned OhlcResponse is keyed by Contract.ExchangeSymbol.
+func (c *Client) GetOhlcFor(ctx context.Context, contracts ...Contract) (OhlcResponse, error) {
+	out := make(OhlcResponse, len(contracts))
+
+	for segment, symbols := range exchangeSymbolsBySegment(contracts) {
+		for batch := range slices.Chunk(symbols, maxLiveDataSymbols) {
+			ohlc, err := c.GetOhlc(ctx, OhlcRequest{Segment: segment, ExchangeSymbols: batch})
+			if err != nil {
+				return nil, fmt.Errorf("GetOhlc(%s): %w", segment, err)
+			}
+
+			maps.Copy(out, ohlc)
+		}
+	}
+
+	return out, nil
+}
+
+func exchangeSymbolsBySegment(contracts []Contract) map[Segment][]string {
+	out := make(map[Segment][]string)
+	for _, contract := range contracts {
+		out[contract.Segment] = append(out[contract.Segment], contract.ExchangeSymbol())
+	}
+
+	return out
+}
+
+// GetHistoricalCandlesFor is Client.GetHistoricalCandles for a Contract
+func (c *Client) GetHistoricalCandlesFor(
+	ctx context.Context,
+	contract Contract,
+	start, end time.Time,
+	interval CandleInterval,
+) (HistoricalCandlesData, error) {
+	return c.GetHistoricalCandles(ctx, contract.HistoricalCandlesRequest(start, end, interval))
+}
+
+// GetGreeksFor is Client.GetGreeks for a Contract
+func (c *Client) GetGreeksFor(ctx context.Context, contract Contract) (Greeks, error) {
+	return c.GetGreeks(ctx, contract.GreeksRequest())
+}
+
+// PlaceOrderFor is Client.PlaceOrder for a Contract. TradingSymbol, Exchange and Segment of req are ignored
+func (c *Client) PlaceOrderFor(ctx context.Context, contract Contract, req PlaceOrderRequest) (PlaceOrderResponse, error) {
+	return c.PlaceOrder(ctx, contract.PlaceOrderRequest(req))
+}
@@ -0,0 +1,210 @@
+package growwapi
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// ExpiryKind represents the kind of derivatives expiry
+type ExpiryKind string
+
+const (
+	// ExpiryKindWeekly - Expiry which is not the last one of its month
+	ExpiryKindWeekly ExpiryKind = "WEEKLY"
+
+	// ExpiryKindMonthly - Last expiry of a month
+	ExpiryKindMonthly ExpiryKind = "MONTHLY"
+
+	// ExpiryKindQuarterly - Last expiry of March, June, September or December
+	ExpiryKindQuarterly ExpiryKind = "QUARTERLY"
+)
+
+// IsMonthly reports whether the kind is the last expiry of its month, which quarterly expiries are as well
+func (k ExpiryKind) IsMonthly() bool {
+	return k == ExpiryKindMonthly || k == ExpiryKindQuarterly
+}
+
+// Expiry represents a derivatives expiry date and its kind
+type Expiry struct {
+	// Date of the expiry, as midnight IST
+	Date time.Time
+	// Kind of the expiry
+	Kind ExpiryKind
+}
+
+// ExpiryCalendar holds the expiry dates of the derivatives of an underlying.
+//
+// Expiries are classified from the actual dates rather than from the weekday they are expected on, so an expiry
+// moved to an earlier day by a holiday is still the monthly expiry if it is the last one of its month.
+type ExpiryCalendar struct {
+	exchange   Exchange
+	underlying string
+	expiries   []Expiry
+}
+
+// AllExpiries returns the expiry dates of the underlying from fromYear to the current year, calling Client.GetExpiries
+// once per year. Data of FNO instruments are available from 2020.
+func (c *Client) AllExpiries(ctx context.Context, exchange Exchange, underlying string, fromYear int) ([]time.Time, error) {
+	return AllExpiries(ctx, c, exchange, underlying, fromYear)
+}
+
+// AllExpiries is Client.AllExpiries for any BacktestingAPI
+func AllExpiries(ctx context.Context, api BacktestingAPI, exchange Exchange, underlying string, fromYear int) ([]time.Time, error) {
+	var out []time.Time
+
+	for year := fromYear; year <= time.Now().In(IST).Year(); year++ {
+		resp, err := api.GetExpiries(ctx, GetExpiriesRequest{Exchange: exchange, UnderlyingSymbol: underlying, Year: year})
+		if err != nil {
+			return nil, fmt.Errorf("GetExpiries(%s, %s, %d): %w", exchange, underlying, year, err)
+		}
+
+		for _, expiry := range resp.Expiries {
+			out = append(out, expiry.Time)
+		}
+	}
+
+	return out, nil
+}
+
+// LoadExpiryCalendar creates an ExpiryCalendar from the expiries returned by Client.GetExpiries since fromYear,
+// merged with the expiries of the underlying's derivatives in instruments. The instrument master lists expiries
+// which are yet to come, while GetExpiries covers the past ones.
+func (c *Client) LoadExpiryCalendar(
+	ctx context.Context,
+	exchange Exchange,
+	underlying string,
+	fromYear int,
+	instruments []Instrument,
+) (*ExpiryCalendar, error) {
+	return LoadExpiryCalendar(ctx, c, exchange, underlying, fromYear, instruments)
+}
+
+// LoadExpiryCalendar is Client.LoadExpiryCalendar for any BacktestingAPI
+func LoadExpiryCalendar(
+	ctx context.Context,
+	api BacktestingAPI,
+	exchange Exchange,
+	underlying string,
+	fromYear int,
+	instruments []Instrument,
+) (*ExpiryCalendar, error) {
+	expiries, err := AllExpiries(ctx, api, exchange, underlying, fromYear)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewExpiryCalendar(exchange, underlying, expiries, instruments), nil
+}
+
+// NewExpiryCalendar creates an ExpiryCalendar from expiry dates, merged with the expiries of the underlying's
+// derivatives in instruments. Either can be empty.
+// The last known expiry of a month is taken as its monthly expiry, so expiries should cover whole months.
+func NewExpiryCalendar(exchange Exchange, underlying string, expiries []time.Time, instruments []Instrument) *ExpiryCalendar {
+	var dates []time.Time
+	for _, expiry := range expiries {
+		dates = append(dates, startOfDate(expiry))
+	}
+
+	for _, instrument := range instruments {
+		if instrument.Exchange == exchange && instrument.UnderlyingSymbol == underlying && instrument.ExpiryDate.Time != nil {
+			year, month, day := instrument.ExpiryDate.Date()
+			dates = append(dates, time.Date(year, month, day, 0, 0, 0, 0, IST))
+		}
+	}
+
+	slices.SortFunc(dates, func(a, b time.Time) int { return a.Compare(b) })
+	dates = slices.CompactFunc(dates, func(a, b time.Time) bool { return a.Equal(b) })
+
+	out := &ExpiryCalendar{exchange: exchange, underlying: underlying, expiries: make([]Expiry, len(dates))}
+	for i, date := range dates {
+		kind := ExpiryKindWeekly
+
+		if i == len(dates)-1 || dates[i+1].Month() != date.Month() || dates[i+1].Year() != date.Year() {
+			kind = ExpiryKindMonthly
+			if date.Month()%3 == 0 {
+				kind = ExpiryKindQuarterly
+			}
+		}
+
+		out.expiries[i] = Expiry{Date: date, Kind: kind}
+	}
+
+	return out
+}
+
+// Exchange returns the exchange of the calendar
+func (c *ExpiryCalendar) Exchange() Exchange {
+	return c.exchange
+}
+
+// Underlying returns the underlying symbol of the calendar
+func (c *ExpiryCalendar) Underlying() string {
+	return c.underlying
+}
+
+// Expiries returns all the expiries in ascending order
+func (c *ExpiryCalendar) Expiries() []Expiry {
+	return slices.Clone(c.expiries)
+}
+
+// Expiry returns the expiry on the date of d
+func (c *ExpiryCalendar) Expiry(d time.Time) (Expiry, bool) {
+	i, found := c.search(d)
+	if !found {
+		return Expiry{}, false
+	}
+
+	return c.expiries[i], true
+}
+
+// IsExpiryDay reports whether there is an expiry on the date of d
+func (c *ExpiryCalendar) IsExpiryDay(d time.Time) bool {
+	_, found := c.search(d)
+	return found
+}
+
+// Current returns the nearest expiry on or after the date of d, which is the current weekly expiry for underlyings
+// with weekly expiries
+func (c *ExpiryCalendar) Current(d time.Time) (Expiry, bool) {
+	return c.next(d, func(Expiry) bool { return true })
+}
+
+// NextMonthly returns the nearest monthly or quarterly expiry on or after the date of d
+func (c *ExpiryCalendar) NextMonthly(d time.Time) (Expiry, bool) {
+	return c.next(d, func(e Expiry) bool { return e.Kind.IsMonthly() })
+}
+
+// NextQuarterly returns the nearest quarterly expiry on or after the date of d
+func (c *ExpiryCalendar) NextQuarterly(d time.Time) (Expiry, bool) {
+	return c.next(d, func(e Expiry) bool { return e.Kind == ExpiryKindQuarterly })
+}
+
+// Previous returns the last expiry before the date of d
+func (c *ExpiryCalendar) Previous(d time.Time) (Expiry, bool) {
+	i, _ := c.search(d)
+	if i == 0 {
+		return Expiry{}, false
+	}
+
+	return c.expiries[i-1], true
+}
+
+func (c *ExpiryCalendar) next(d time.Time, match func(Expiry) bool) (Expiry, bool) {
+	i, _ := c.search(d)
+	for ; i < len(c.expiries); i++ {
+		if match(c.expiries[i]) {
+			return c.expiries[i], true
+		}
+	}
+
+	return Expiry{}, false
+}
+
+// search returns the position of the first expiry on or after the date of d
+func (c *ExpiryCalendar) search(d time.Time) (int, bool) {
+	return slices.BinarySearchFunc(c.expiries, startOfDate(d), func(e Expiry, date time.Time) int {
+		return e.Date.Compare(date)
+	})
+}
@@ -2,4 +2,7 @@ module github.com/rctrj/growwapi-go
 
 go 1.25.4
 
-require github.com/gocarina/gocsv v0.0.0-20240520201108-78e41c74b4b1
+require (
+	github.com/gocarina/gocsv v0.0.0-20240520201108-78e41c74b4b1
+	go.uber.org/mock v0.6.0
+)
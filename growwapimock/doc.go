@@ -0,0 +1,9 @@
+// Package growwapimock provides gomock mocks of the growwapi interfaces for tests.
+//
+// The mocks are generated by mockgen, run go generate after changing the interfaces in api.go.
+// Helpers of growwapi.Client which are built on the interfaces have package level counterparts taking the
+// interface, such as growwapi.AllOrders, growwapi.FetchCandles and growwapi.LoadExpiryCalendar, which work with
+// the mocks as well.
+package growwapimock
+
+//go:generate go run go.uber.org/mock/mockgen@v0.6.0 -destination=mock.go -package=growwapimock -typed github.com/rctrj/growwapi-go API,OrdersAPI,LiveDataAPI,BacktestingAPI,InstrumentsAPI,QuoteSource
@@ -0,0 +1,1371 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/rctrj/growwapi-go (interfaces: API,OrdersAPI,LiveDataAPI,BacktestingAPI,InstrumentsAPI,QuoteSource)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mock.go -package=growwapimock -typed github.com/rctrj/growwapi-go API,OrdersAPI,LiveDataAPI,BacktestingAPI,InstrumentsAPI,QuoteSource
+//
+
+// Package growwapimock is a generated GoMock package.
+package growwapimock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	growwapi "github.com/rctrj/growwapi-go"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAPI is a mock of API interface.
+type MockAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPIMockRecorder
+	isgomock struct{}
+}
+
+// MockAPIMockRecorder is the mock recorder for MockAPI.
+type MockAPIMockRecorder struct {
+	mock *MockAPI
+}
+
+// NewMockAPI creates a new mock instance.
+func NewMockAPI(ctrl *gomock.Controller) *MockAPI {
+	mock := &MockAPI{ctrl: ctrl}
+	mock.recorder = &MockAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPI) EXPECT() *MockAPIMockRecorder {
+	return m.recorder
+}
+
+// CancelOrder mocks base method.
+func (m *MockAPI) CancelOrder(ctx context.Context, req growwapi.CancelOrderRequest) (growwapi.CancelOrderResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelOrder", ctx, req)
+	ret0, _ := ret[0].(growwapi.CancelOrderResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelOrder indicates an expected call of CancelOrder.
+func (mr *MockAPIMockRecorder) CancelOrder(ctx, req any) *MockAPICancelOrderCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelOrder", reflect.TypeOf((*MockAPI)(nil).CancelOrder), ctx, req)
+	return &MockAPICancelOrderCall{Call: call}
+}
+
+// MockAPICancelOrderCall wrap *gomock.Call
+type MockAPICancelOrderCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAPICancelOrderCall) Return(arg0 growwapi.CancelOrderResponse, arg1 error) *MockAPICancelOrderCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAPICancelOrderCall) Do(f func(context.Context, growwapi.CancelOrderRequest) (growwapi.CancelOrderResponse, error)) *MockAPICancelOrderCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAPICancelOrderCall) DoAndReturn(f func(context.Context, growwapi.CancelOrderRequest) (growwapi.CancelOrderResponse, error)) *MockAPICancelOrderCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetContracts mocks base method.
+func (m *MockAPI) GetContracts(ctx context.Context, req growwapi.GetContractsRequest) (growwapi.GetContractsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContracts", ctx, req)
+	ret0, _ := ret[0].(growwapi.GetContractsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContracts indicates an expected call of GetContracts.
+func (mr *MockAPIMockRecorder) GetContracts(ctx, req any) *MockAPIGetContractsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContracts", reflect.TypeOf((*MockAPI)(nil).GetContracts), ctx, req)
+	return &MockAPIGetContractsCall{Call: call}
+}
+
+// MockAPIGetContractsCall wrap *gomock.Call
+type MockAPIGetContractsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAPIGetContractsCall) Return(arg0 growwapi.GetContractsResponse, arg1 error) *MockAPIGetContractsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAPIGetContractsCall) Do(f func(context.Context, growwapi.GetContractsRequest) (growwapi.GetContractsResponse, error)) *MockAPIGetContractsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAPIGetContractsCall) DoAndReturn(f func(context.Context, growwapi.GetContractsRequest) (growwapi.GetContractsResponse, error)) *MockAPIGetContractsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetExpiries mocks base method.
+func (m *MockAPI) GetExpiries(ctx context.Context, req growwapi.GetExpiriesRequest) (growwapi.GetExpiriesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpiries", ctx, req)
+	ret0, _ := ret[0].(growwapi.GetExpiriesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExpiries indicates an expected call of GetExpiries.
+func (mr *MockAPIMockRecorder) GetExpiries(ctx, req any) *MockAPIGetExpiriesCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpiries", reflect.TypeOf((*MockAPI)(nil).GetExpiries), ctx, req)
+	return &MockAPIGetExpiriesCall{Call: call}
+}
+
+// MockAPIGetExpiriesCall wrap *gomock.Call
+type MockAPIGetExpiriesCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAPIGetExpiriesCall) Return(arg0 growwapi.GetExpiriesResponse, arg1 error) *MockAPIGetExpiriesCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAPIGetExpiriesCall) Do(f func(context.Context, growwapi.GetExpiriesRequest) (growwapi.GetExpiriesResponse, error)) *MockAPIGetExpiriesCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAPIGetExpiriesCall) DoAndReturn(f func(context.Context, growwapi.GetExpiriesRequest) (growwapi.GetExpiriesResponse, error)) *MockAPIGetExpiriesCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetGreeks mocks base method.
+func (m *MockAPI) GetGreeks(ctx context.Context, req growwapi.GetGreeksRequest) (growwapi.Greeks, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGreeks", ctx, req)
+	ret0, _ := ret[0].(growwapi.Greeks)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGreeks indicates an expected call of GetGreeks.
+func (mr *MockAPIMockRecorder) GetGreeks(ctx, req any) *MockAPIGetGreeksCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGreeks", reflect.TypeOf((*MockAPI)(nil).GetGreeks), ctx, req)
+	return &MockAPIGetGreeksCall{Call: call}
+}
+
+// MockAPIGetGreeksCall wrap *gomock.Call
+type MockAPIGetGreeksCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAPIGetGreeksCall) Return(arg0 growwapi.Greeks, arg1 error) *MockAPIGetGreeksCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAPIGetGreeksCall) Do(f func(context.Context, growwapi.GetGreeksRequest) (growwapi.Greeks, error)) *MockAPIGetGreeksCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAPIGetGreeksCall) DoAndReturn(f func(context.Context, growwapi.GetGreeksRequest) (growwapi.Greeks, error)) *MockAPIGetGreeksCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetHistoricalCandles mocks base method.
+func (m *MockAPI) GetHistoricalCandles(ctx context.Context, req growwapi.GetHistoricalCandlesRequest) (growwapi.HistoricalCandlesData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHistoricalCandles", ctx, req)
+	ret0, _ := ret[0].(growwapi.HistoricalCandlesData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHistoricalCandles indicates an expected call of GetHistoricalCandles.
+func (mr *MockAPIMockRecorder) GetHistoricalCandles(ctx, req any) *MockAPIGetHistoricalCandlesCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistoricalCandles", reflect.TypeOf((*MockAPI)(nil).GetHistoricalCandles), ctx, req)
+	return &MockAPIGetHistoricalCandlesCall{Call: call}
+}
+
+// MockAPIGetHistoricalCandlesCall wrap *gomock.Call
+type MockAPIGetHistoricalCandlesCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAPIGetHistoricalCandlesCall) Return(arg0 growwapi.HistoricalCandlesData, arg1 error) *MockAPIGetHistoricalCandlesCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAPIGetHistoricalCandlesCall) Do(f func(context.Context, growwapi.GetHistoricalCandlesRequest) (growwapi.HistoricalCandlesData, error)) *MockAPIGetHistoricalCandlesCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAPIGetHistoricalCandlesCall) DoAndReturn(f func(context.Context, growwapi.GetHistoricalCandlesRequest) (growwapi.HistoricalCandlesData, error)) *MockAPIGetHistoricalCandlesCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetLtp mocks base method.
+func (m *MockAPI) GetLtp(ctx context.Context, req growwapi.LtpRequest) (growwapi.Ltp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLtp", ctx, req)
+	ret0, _ := ret[0].(growwapi.Ltp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLtp indicates an expected call of GetLtp.
+func (mr *MockAPIMockRecorder) GetLtp(ctx, req any) *MockAPIGetLtpCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLtp", reflect.TypeOf((*MockAPI)(nil).GetLtp), ctx, req)
+	return &MockAPIGetLtpCall{Call: call}
+}
+
+// MockAPIGetLtpCall wrap *gomock.Call
+type MockAPIGetLtpCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAPIGetLtpCall) Return(arg0 growwapi.Ltp, arg1 error) *MockAPIGetLtpCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAPIGetLtpCall) Do(f func(context.Context, growwapi.LtpRequest) (growwapi.Ltp, error)) *MockAPIGetLtpCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAPIGetLtpCall) DoAndReturn(f func(context.Context, growwapi.LtpRequest) (growwapi.Ltp, error)) *MockAPIGetLtpCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetOhlc mocks base method.
+func (m *MockAPI) GetOhlc(ctx context.Context, req growwapi.OhlcRequest) (growwapi.OhlcResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOhlc", ctx, req)
+	ret0, _ := ret[0].(growwapi.OhlcResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOhlc indicates an expected call of GetOhlc.
+func (mr *MockAPIMockRecorder) GetOhlc(ctx, req any) *MockAPIGetOhlcCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOhlc", reflect.TypeOf((*MockAPI)(nil).GetOhlc), ctx, req)
+	return &MockAPIGetOhlcCall{Call: call}
+}
+
+// MockAPIGetOhlcCall wrap *gomock.Call
+type MockAPIGetOhlcCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAPIGetOhlcCall) Return(arg0 growwapi.OhlcResponse, arg1 error) *MockAPIGetOhlcCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAPIGetOhlcCall) Do(f func(context.Context, growwapi.OhlcRequest) (growwapi.OhlcResponse, error)) *MockAPIGetOhlcCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAPIGetOhlcCall) DoAndReturn(f func(context.Context, growwapi.OhlcRequest) (growwapi.OhlcResponse, error)) *MockAPIGetOhlcCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetOrderDetails mocks base method.
+func (m *MockAPI) GetOrderDetails(ctx context.Context, req growwapi.GetOrderDetailsRequest) (growwapi.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderDetails", ctx, req)
+	ret0, _ := ret[0].(growwapi.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderDetails indicates an expected call of GetOrderDetails.
+func (mr *MockAPIMockRecorder) GetOrderDetails(ctx, req any) *MockAPIGetOrderDetailsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderDetails", reflect.TypeOf((*MockAPI)(nil).GetOrderDetails), ctx, req)
+	return &MockAPIGetOrderDetailsCall{Call: call}
+}
+
+// MockAPIGetOrderDetailsCall wrap *gomock.Call
+type MockAPIGetOrderDetailsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAPIGetOrderDetailsCall) Return(arg0 growwapi.Order, arg1 error) *MockAPIGetOrderDetailsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAPIGetOrderDetailsCall) Do(f func(context.Context, growwapi.GetOrderDetailsRequest) (growwapi.Order, error)) *MockAPIGetOrderDetailsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAPIGetOrderDetailsCall) DoAndReturn(f func(context.Context, growwapi.GetOrderDetailsRequest) (growwapi.Order, error)) *MockAPIGetOrderDetailsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetOrderStatus mocks base method.
+func (m *MockAPI) GetOrderStatus(ctx context.Context, req growwapi.OrderStatusRequest) (growwapi.OrderStatusResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderStatus", ctx, req)
+	ret0, _ := ret[0].(growwapi.OrderStatusResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderStatus indicates an expected call of GetOrderStatus.
+func (mr *MockAPIMockRecorder) GetOrderStatus(ctx, req any) *MockAPIGetOrderStatusCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderStatus", reflect.TypeOf((*MockAPI)(nil).GetOrderStatus), ctx, req)
+	return &MockAPIGetOrderStatusCall{Call: call}
+}
+
+// MockAPIGetOrderStatusCall wrap *gomock.Call
+type MockAPIGetOrderStatusCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAPIGetOrderStatusCall) Return(arg0 growwapi.OrderStatusResponse, arg1 error) *MockAPIGetOrderStatusCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAPIGetOrderStatusCall) Do(f func(context.Context, growwapi.OrderStatusRequest) (growwapi.OrderStatusResponse, error)) *MockAPIGetOrderStatusCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAPIGetOrderStatusCall) DoAndReturn(f func(context.Context, growwapi.OrderStatusRequest) (growwapi.OrderStatusResponse, error)) *MockAPIGetOrderStatusCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetQuote mocks base method.
+func (m *MockAPI) GetQuote(ctx context.Context, req growwapi.QuoteRequest) (growwapi.Quote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQuote", ctx, req)
+	ret0, _ := ret[0].(growwapi.Quote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQuote indicates an expected call of GetQuote.
+func (mr *MockAPIMockRecorder) GetQuote(ctx, req any) *MockAPIGetQuoteCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuote", reflect.TypeOf((*MockAPI)(nil).GetQuote), ctx, req)
+	return &MockAPIGetQuoteCall{Call: call}
+}
+
+// MockAPIGetQuoteCall wrap *gomock.Call
+type MockAPIGetQuoteCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAPIGetQuoteCall) Return(arg0 growwapi.Quote, arg1 error) *MockAPIGetQuoteCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAPIGetQuoteCall) Do(f func(context.Context, growwapi.QuoteRequest) (growwapi.Quote, error)) *MockAPIGetQuoteCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAPIGetQuoteCall) DoAndReturn(f func(context.Context, growwapi.QuoteRequest) (growwapi.Quote, error)) *MockAPIGetQuoteCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetTradesForOrder mocks base method.
+func (m *MockAPI) GetTradesForOrder(ctx context.Context, req growwapi.TradesForOrderRequest) ([]growwapi.Trade, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTradesForOrder", ctx, req)
+	ret0, _ := ret[0].([]growwapi.Trade)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTradesForOrder indicates an expected call of GetTradesForOrder.
+func (mr *MockAPIMockRecorder) GetTradesForOrder(ctx, req any) *MockAPIGetTradesForOrderCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTradesForOrder", reflect.TypeOf((*MockAPI)(nil).GetTradesForOrder), ctx, req)
+	return &MockAPIGetTradesForOrderCall{Call: call}
+}
+
+// MockAPIGetTradesForOrderCall wrap *gomock.Call
+type MockAPIGetTradesForOrderCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAPIGetTradesForOrderCall) Return(arg0 []growwapi.Trade, arg1 error) *MockAPIGetTradesForOrderCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAPIGetTradesForOrderCall) Do(f func(context.Context, growwapi.TradesForOrderRequest) ([]growwapi.Trade, error)) *MockAPIGetTradesForOrderCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAPIGetTradesForOrderCall) DoAndReturn(f func(context.Context, growwapi.TradesForOrderRequest) ([]growwapi.Trade, error)) *MockAPIGetTradesForOrderCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Instruments mocks base method.
+func (m *MockAPI) Instruments(ctx context.Context) ([]growwapi.Instrument, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Instruments", ctx)
+	ret0, _ := ret[0].([]growwapi.Instrument)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Instruments indicates an expected call of Instruments.
+func (mr *MockAPIMockRecorder) Instruments(ctx any) *MockAPIInstrumentsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Instruments", reflect.TypeOf((*MockAPI)(nil).Instruments), ctx)
+	return &MockAPIInstrumentsCall{Call: call}
+}
+
+// MockAPIInstrumentsCall wrap *gomock.Call
+type MockAPIInstrumentsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAPIInstrumentsCall) Return(arg0 []growwapi.Instrument, arg1 error) *MockAPIInstrumentsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAPIInstrumentsCall) Do(f func(context.Context) ([]growwapi.Instrument, error)) *MockAPIInstrumentsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAPIInstrumentsCall) DoAndReturn(f func(context.Context) ([]growwapi.Instrument, error)) *MockAPIInstrumentsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListOrders mocks base method.
+func (m *MockAPI) ListOrders(ctx context.Context, req growwapi.ListOrdersRequest) ([]growwapi.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOrders", ctx, req)
+	ret0, _ := ret[0].([]growwapi.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOrders indicates an expected call of ListOrders.
+func (mr *MockAPIMockRecorder) ListOrders(ctx, req any) *MockAPIListOrdersCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrders", reflect.TypeOf((*MockAPI)(nil).ListOrders), ctx, req)
+	return &MockAPIListOrdersCall{Call: call}
+}
+
+// MockAPIListOrdersCall wrap *gomock.Call
+type MockAPIListOrdersCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAPIListOrdersCall) Return(arg0 []growwapi.Order, arg1 error) *MockAPIListOrdersCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAPIListOrdersCall) Do(f func(context.Context, growwapi.ListOrdersRequest) ([]growwapi.Order, error)) *MockAPIListOrdersCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAPIListOrdersCall) DoAndReturn(f func(context.Context, growwapi.ListOrdersRequest) ([]growwapi.Order, error)) *MockAPIListOrdersCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ModifyOrder mocks base method.
+func (m *MockAPI) ModifyOrder(ctx context.Context, req growwapi.ModifyOrderRequest) (growwapi.ModifyOrderResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ModifyOrder", ctx, req)
+	ret0, _ := ret[0].(growwapi.ModifyOrderResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ModifyOrder indicates an expected call of ModifyOrder.
+func (mr *MockAPIMockRecorder) ModifyOrder(ctx, req any) *MockAPIModifyOrderCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModifyOrder", reflect.TypeOf((*MockAPI)(nil).ModifyOrder), ctx, req)
+	return &MockAPIModifyOrderCall{Call: call}
+}
+
+// MockAPIModifyOrderCall wrap *gomock.Call
+type MockAPIModifyOrderCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAPIModifyOrderCall) Return(arg0 growwapi.ModifyOrderResponse, arg1 error) *MockAPIModifyOrderCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAPIModifyOrderCall) Do(f func(context.Context, growwapi.ModifyOrderRequest) (growwapi.ModifyOrderResponse, error)) *MockAPIModifyOrderCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAPIModifyOrderCall) DoAndReturn(f func(context.Context, growwapi.ModifyOrderRequest) (growwapi.ModifyOrderResponse, error)) *MockAPIModifyOrderCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// PlaceOrder mocks base method.
+func (m *MockAPI) PlaceOrder(ctx context.Context, req growwapi.PlaceOrderRequest) (growwapi.PlaceOrderResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PlaceOrder", ctx, req)
+	ret0, _ := ret[0].(growwapi.PlaceOrderResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PlaceOrder indicates an expected call of PlaceOrder.
+func (mr *MockAPIMockRecorder) PlaceOrder(ctx, req any) *MockAPIPlaceOrderCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PlaceOrder", reflect.TypeOf((*MockAPI)(nil).PlaceOrder), ctx, req)
+	return &MockAPIPlaceOrderCall{Call: call}
+}
+
+// MockAPIPlaceOrderCall wrap *gomock.Call
+type MockAPIPlaceOrderCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAPIPlaceOrderCall) Return(arg0 growwapi.PlaceOrderResponse, arg1 error) *MockAPIPlaceOrderCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAPIPlaceOrderCall) Do(f func(context.Context, growwapi.PlaceOrderRequest) (growwapi.PlaceOrderResponse, error)) *MockAPIPlaceOrderCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAPIPlaceOrderCall) DoAndReturn(f func(context.Context, growwapi.PlaceOrderRequest) (growwapi.PlaceOrderResponse, error)) *MockAPIPlaceOrderCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockOrdersAPI is a mock of OrdersAPI interface.
+type MockOrdersAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrdersAPIMockRecorder
+	isgomock struct{}
+}
+
+// MockOrdersAPIMockRecorder is the mock recorder for MockOrdersAPI.
+type MockOrdersAPIMockRecorder struct {
+	mock *MockOrdersAPI
+}
+
+// NewMockOrdersAPI creates a new mock instance.
+func NewMockOrdersAPI(ctrl *gomock.Controller) *MockOrdersAPI {
+	mock := &MockOrdersAPI{ctrl: ctrl}
+	mock.recorder = &MockOrdersAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrdersAPI) EXPECT() *MockOrdersAPIMockRecorder {
+	return m.recorder
+}
+
+// CancelOrder mocks base method.
+func (m *MockOrdersAPI) CancelOrder(ctx context.Context, req growwapi.CancelOrderRequest) (growwapi.CancelOrderResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelOrder", ctx, req)
+	ret0, _ := ret[0].(growwapi.CancelOrderResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelOrder indicates an expected call of CancelOrder.
+func (mr *MockOrdersAPIMockRecorder) CancelOrder(ctx, req any) *MockOrdersAPICancelOrderCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelOrder", reflect.TypeOf((*MockOrdersAPI)(nil).CancelOrder), ctx, req)
+	return &MockOrdersAPICancelOrderCall{Call: call}
+}
+
+// MockOrdersAPICancelOrderCall wrap *gomock.Call
+type MockOrdersAPICancelOrderCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockOrdersAPICancelOrderCall) Return(arg0 growwapi.CancelOrderResponse, arg1 error) *MockOrdersAPICancelOrderCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockOrdersAPICancelOrderCall) Do(f func(context.Context, growwapi.CancelOrderRequest) (growwapi.CancelOrderResponse, error)) *MockOrdersAPICancelOrderCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockOrdersAPICancelOrderCall) DoAndReturn(f func(context.Context, growwapi.CancelOrderRequest) (growwapi.CancelOrderResponse, error)) *MockOrdersAPICancelOrderCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetOrderDetails mocks base method.
+func (m *MockOrdersAPI) GetOrderDetails(ctx context.Context, req growwapi.GetOrderDetailsRequest) (growwapi.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderDetails", ctx, req)
+	ret0, _ := ret[0].(growwapi.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderDetails indicates an expected call of GetOrderDetails.
+func (mr *MockOrdersAPIMockRecorder) GetOrderDetails(ctx, req any) *MockOrdersAPIGetOrderDetailsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderDetails", reflect.TypeOf((*MockOrdersAPI)(nil).GetOrderDetails), ctx, req)
+	return &MockOrdersAPIGetOrderDetailsCall{Call: call}
+}
+
+// MockOrdersAPIGetOrderDetailsCall wrap *gomock.Call
+type MockOrdersAPIGetOrderDetailsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockOrdersAPIGetOrderDetailsCall) Return(arg0 growwapi.Order, arg1 error) *MockOrdersAPIGetOrderDetailsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockOrdersAPIGetOrderDetailsCall) Do(f func(context.Context, growwapi.GetOrderDetailsRequest) (growwapi.Order, error)) *MockOrdersAPIGetOrderDetailsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockOrdersAPIGetOrderDetailsCall) DoAndReturn(f func(context.Context, growwapi.GetOrderDetailsRequest) (growwapi.Order, error)) *MockOrdersAPIGetOrderDetailsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetOrderStatus mocks base method.
+func (m *MockOrdersAPI) GetOrderStatus(ctx context.Context, req growwapi.OrderStatusRequest) (growwapi.OrderStatusResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderStatus", ctx, req)
+	ret0, _ := ret[0].(growwapi.OrderStatusResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderStatus indicates an expected call of GetOrderStatus.
+func (mr *MockOrdersAPIMockRecorder) GetOrderStatus(ctx, req any) *MockOrdersAPIGetOrderStatusCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderStatus", reflect.TypeOf((*MockOrdersAPI)(nil).GetOrderStatus), ctx, req)
+	return &MockOrdersAPIGetOrderStatusCall{Call: call}
+}
+
+// MockOrdersAPIGetOrderStatusCall wrap *gomock.Call
+type MockOrdersAPIGetOrderStatusCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockOrdersAPIGetOrderStatusCall) Return(arg0 growwapi.OrderStatusResponse, arg1 error) *MockOrdersAPIGetOrderStatusCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockOrdersAPIGetOrderStatusCall) Do(f func(context.Context, growwapi.OrderStatusRequest) (growwapi.OrderStatusResponse, error)) *MockOrdersAPIGetOrderStatusCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockOrdersAPIGetOrderStatusCall) DoAndReturn(f func(context.Context, growwapi.OrderStatusRequest) (growwapi.OrderStatusResponse, error)) *MockOrdersAPIGetOrderStatusCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetTradesForOrder mocks base method.
+func (m *MockOrdersAPI) GetTradesForOrder(ctx context.Context, req growwapi.TradesForOrderRequest) ([]growwapi.Trade, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTradesForOrder", ctx, req)
+	ret0, _ := ret[0].([]growwapi.Trade)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTradesForOrder indicates an expected call of GetTradesForOrder.
+func (mr *MockOrdersAPIMockRecorder) GetTradesForOrder(ctx, req any) *MockOrdersAPIGetTradesForOrderCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTradesForOrder", reflect.TypeOf((*MockOrdersAPI)(nil).GetTradesForOrder), ctx, req)
+	return &MockOrdersAPIGetTradesForOrderCall{Call: call}
+}
+
+// MockOrdersAPIGetTradesForOrderCall wrap *gomock.Call
+type MockOrdersAPIGetTradesForOrderCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockOrdersAPIGetTradesForOrderCall) Return(arg0 []growwapi.Trade, arg1 error) *MockOrdersAPIGetTradesForOrderCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockOrdersAPIGetTradesForOrderCall) Do(f func(context.Context, growwapi.TradesForOrderRequest) ([]growwapi.Trade, error)) *MockOrdersAPIGetTradesForOrderCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockOrdersAPIGetTradesForOrderCall) DoAndReturn(f func(context.Context, growwapi.TradesForOrderRequest) ([]growwapi.Trade, error)) *MockOrdersAPIGetTradesForOrderCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListOrders mocks base method.
+func (m *MockOrdersAPI) ListOrders(ctx context.Context, req growwapi.ListOrdersRequest) ([]growwapi.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOrders", ctx, req)
+	ret0, _ := ret[0].([]growwapi.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOrders indicates an expected call of ListOrders.
+func (mr *MockOrdersAPIMockRecorder) ListOrders(ctx, req any) *MockOrdersAPIListOrdersCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrders", reflect.TypeOf((*MockOrdersAPI)(nil).ListOrders), ctx, req)
+	return &MockOrdersAPIListOrdersCall{Call: call}
+}
+
+// MockOrdersAPIListOrdersCall wrap *gomock.Call
+type MockOrdersAPIListOrdersCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockOrdersAPIListOrdersCall) Return(arg0 []growwapi.Order, arg1 error) *MockOrdersAPIListOrdersCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockOrdersAPIListOrdersCall) Do(f func(context.Context, growwapi.ListOrdersRequest) ([]growwapi.Order, error)) *MockOrdersAPIListOrdersCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockOrdersAPIListOrdersCall) DoAndReturn(f func(context.Context, growwapi.ListOrdersRequest) ([]growwapi.Order, error)) *MockOrdersAPIListOrdersCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ModifyOrder mocks base method.
+func (m *MockOrdersAPI) ModifyOrder(ctx context.Context, req growwapi.ModifyOrderRequest) (growwapi.ModifyOrderResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ModifyOrder", ctx, req)
+	ret0, _ := ret[0].(growwapi.ModifyOrderResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ModifyOrder indicates an expected call of ModifyOrder.
+func (mr *MockOrdersAPIMockRecorder) ModifyOrder(ctx, req any) *MockOrdersAPIModifyOrderCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModifyOrder", reflect.TypeOf((*MockOrdersAPI)(nil).ModifyOrder), ctx, req)
+	return &MockOrdersAPIModifyOrderCall{Call: call}
+}
+
+// MockOrdersAPIModifyOrderCall wrap *gomock.Call
+type MockOrdersAPIModifyOrderCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockOrdersAPIModifyOrderCall) Return(arg0 growwapi.ModifyOrderResponse, arg1 error) *MockOrdersAPIModifyOrderCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockOrdersAPIModifyOrderCall) Do(f func(context.Context, growwapi.ModifyOrderRequest) (growwapi.ModifyOrderResponse, error)) *MockOrdersAPIModifyOrderCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockOrdersAPIModifyOrderCall) DoAndReturn(f func(context.Context, growwapi.ModifyOrderRequest) (growwapi.ModifyOrderResponse, error)) *MockOrdersAPIModifyOrderCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// PlaceOrder mocks base method.
+func (m *MockOrdersAPI) PlaceOrder(ctx context.Context, req growwapi.PlaceOrderRequest) (growwapi.PlaceOrderResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PlaceOrder", ctx, req)
+	ret0, _ := ret[0].(growwapi.PlaceOrderResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PlaceOrder indicates an expected call of PlaceOrder.
+func (mr *MockOrdersAPIMockRecorder) PlaceOrder(ctx, req any) *MockOrdersAPIPlaceOrderCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PlaceOrder", reflect.TypeOf((*MockOrdersAPI)(nil).PlaceOrder), ctx, req)
+	return &MockOrdersAPIPlaceOrderCall{Call: call}
+}
+
+// MockOrdersAPIPlaceOrderCall wrap *gomock.Call
+type MockOrdersAPIPlaceOrderCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockOrdersAPIPlaceOrderCall) Return(arg0 growwapi.PlaceOrderResponse, arg1 error) *MockOrdersAPIPlaceOrderCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockOrdersAPIPlaceOrderCall) Do(f func(context.Context, growwapi.PlaceOrderRequest) (growwapi.PlaceOrderResponse, error)) *MockOrdersAPIPlaceOrderCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockOrdersAPIPlaceOrderCall) DoAndReturn(f func(context.Context, growwapi.PlaceOrderRequest) (growwapi.PlaceOrderResponse, error)) *MockOrdersAPIPlaceOrderCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockLiveDataAPI is a mock of LiveDataAPI interface.
+type MockLiveDataAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockLiveDataAPIMockRecorder
+	isgomock struct{}
+}
+
+// MockLiveDataAPIMockRecorder is the mock recorder for MockLiveDataAPI.
+type MockLiveDataAPIMockRecorder struct {
+	mock *MockLiveDataAPI
+}
+
+// NewMockLiveDataAPI creates a new mock instance.
+func NewMockLiveDataAPI(ctrl *gomock.Controller) *MockLiveDataAPI {
+	mock := &MockLiveDataAPI{ctrl: ctrl}
+	mock.recorder = &MockLiveDataAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLiveDataAPI) EXPECT() *MockLiveDataAPIMockRecorder {
+	return m.recorder
+}
+
+// GetGreeks mocks base method.
+func (m *MockLiveDataAPI) GetGreeks(ctx context.Context, req growwapi.GetGreeksRequest) (growwapi.Greeks, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGreeks", ctx, req)
+	ret0, _ := ret[0].(growwapi.Greeks)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGreeks indicates an expected call of GetGreeks.
+func (mr *MockLiveDataAPIMockRecorder) GetGreeks(ctx, req any) *MockLiveDataAPIGetGreeksCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGreeks", reflect.TypeOf((*MockLiveDataAPI)(nil).GetGreeks), ctx, req)
+	return &MockLiveDataAPIGetGreeksCall{Call: call}
+}
+
+// MockLiveDataAPIGetGreeksCall wrap *gomock.Call
+type MockLiveDataAPIGetGreeksCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockLiveDataAPIGetGreeksCall) Return(arg0 growwapi.Greeks, arg1 error) *MockLiveDataAPIGetGreeksCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockLiveDataAPIGetGreeksCall) Do(f func(context.Context, growwapi.GetGreeksRequest) (growwapi.Greeks, error)) *MockLiveDataAPIGetGreeksCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockLiveDataAPIGetGreeksCall) DoAndReturn(f func(context.Context, growwapi.GetGreeksRequest) (growwapi.Greeks, error)) *MockLiveDataAPIGetGreeksCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetLtp mocks base method.
+func (m *MockLiveDataAPI) GetLtp(ctx context.Context, req growwapi.LtpRequest) (growwapi.Ltp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLtp", ctx, req)
+	ret0, _ := ret[0].(growwapi.Ltp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLtp indicates an expected call of GetLtp.
+func (mr *MockLiveDataAPIMockRecorder) GetLtp(ctx, req any) *MockLiveDataAPIGetLtpCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLtp", reflect.TypeOf((*MockLiveDataAPI)(nil).GetLtp), ctx, req)
+	return &MockLiveDataAPIGetLtpCall{Call: call}
+}
+
+// MockLiveDataAPIGetLtpCall wrap *gomock.Call
+type MockLiveDataAPIGetLtpCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockLiveDataAPIGetLtpCall) Return(arg0 growwapi.Ltp, arg1 error) *MockLiveDataAPIGetLtpCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockLiveDataAPIGetLtpCall) Do(f func(context.Context, growwapi.LtpRequest) (growwapi.Ltp, error)) *MockLiveDataAPIGetLtpCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockLiveDataAPIGetLtpCall) DoAndReturn(f func(context.Context, growwapi.LtpRequest) (growwapi.Ltp, error)) *MockLiveDataAPIGetLtpCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetOhlc mocks base method.
+func (m *MockLiveDataAPI) GetOhlc(ctx context.Context, req growwapi.OhlcRequest) (growwapi.OhlcResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOhlc", ctx, req)
+	ret0, _ := ret[0].(growwapi.OhlcResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOhlc indicates an expected call of GetOhlc.
+func (mr *MockLiveDataAPIMockRecorder) GetOhlc(ctx, req any) *MockLiveDataAPIGetOhlcCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOhlc", reflect.TypeOf((*MockLiveDataAPI)(nil).GetOhlc), ctx, req)
+	return &MockLiveDataAPIGetOhlcCall{Call: call}
+}
+
+// MockLiveDataAPIGetOhlcCall wrap *gomock.Call
+type MockLiveDataAPIGetOhlcCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockLiveDataAPIGetOhlcCall) Return(arg0 growwapi.OhlcResponse, arg1 error) *MockLiveDataAPIGetOhlcCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockLiveDataAPIGetOhlcCall) Do(f func(context.Context, growwapi.OhlcRequest) (growwapi.OhlcResponse, error)) *MockLiveDataAPIGetOhlcCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockLiveDataAPIGetOhlcCall) DoAndReturn(f func(context.Context, growwapi.OhlcRequest) (growwapi.OhlcResponse, error)) *MockLiveDataAPIGetOhlcCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetQuote mocks base method.
+func (m *MockLiveDataAPI) GetQuote(ctx context.Context, req growwapi.QuoteRequest) (growwapi.Quote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQuote", ctx, req)
+	ret0, _ := ret[0].(growwapi.Quote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQuote indicates an expected call of GetQuote.
+func (mr *MockLiveDataAPIMockRecorder) GetQuote(ctx, req any) *MockLiveDataAPIGetQuoteCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuote", reflect.TypeOf((*MockLiveDataAPI)(nil).GetQuote), ctx, req)
+	return &MockLiveDataAPIGetQuoteCall{Call: call}
+}
+
+// MockLiveDataAPIGetQuoteCall wrap *gomock.Call
+type MockLiveDataAPIGetQuoteCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockLiveDataAPIGetQuoteCall) Return(arg0 growwapi.Quote, arg1 error) *MockLiveDataAPIGetQuoteCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockLiveDataAPIGetQuoteCall) Do(f func(context.Context, growwapi.QuoteRequest) (growwapi.Quote, error)) *MockLiveDataAPIGetQuoteCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockLiveDataAPIGetQuoteCall) DoAndReturn(f func(context.Context, growwapi.QuoteRequest) (growwapi.Quote, error)) *MockLiveDataAPIGetQuoteCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockBacktestingAPI is a mock of BacktestingAPI interface.
+type MockBacktestingAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockBacktestingAPIMockRecorder
+	isgomock struct{}
+}
+
+// MockBacktestingAPIMockRecorder is the mock recorder for MockBacktestingAPI.
+type MockBacktestingAPIMockRecorder struct {
+	mock *MockBacktestingAPI
+}
+
+// NewMockBacktestingAPI creates a new mock instance.
+func NewMockBacktestingAPI(ctrl *gomock.Controller) *MockBacktestingAPI {
+	mock := &MockBacktestingAPI{ctrl: ctrl}
+	mock.recorder = &MockBacktestingAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBacktestingAPI) EXPECT() *MockBacktestingAPIMockRecorder {
+	return m.recorder
+}
+
+// GetContracts mocks base method.
+func (m *MockBacktestingAPI) GetContracts(ctx context.Context, req growwapi.GetContractsRequest) (growwapi.GetContractsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContracts", ctx, req)
+	ret0, _ := ret[0].(growwapi.GetContractsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContracts indicates an expected call of GetContracts.
+func (mr *MockBacktestingAPIMockRecorder) GetContracts(ctx, req any) *MockBacktestingAPIGetContractsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContracts", reflect.TypeOf((*MockBacktestingAPI)(nil).GetContracts), ctx, req)
+	return &MockBacktestingAPIGetContractsCall{Call: call}
+}
+
+// MockBacktestingAPIGetContractsCall wrap *gomock.Call
+type MockBacktestingAPIGetContractsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockBacktestingAPIGetContractsCall) Return(arg0 growwapi.GetContractsResponse, arg1 error) *MockBacktestingAPIGetContractsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockBacktestingAPIGetContractsCall) Do(f func(context.Context, growwapi.GetContractsRequest) (growwapi.GetContractsResponse, error)) *MockBacktestingAPIGetContractsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockBacktestingAPIGetContractsCall) DoAndReturn(f func(context.Context, growwapi.GetContractsRequest) (growwapi.GetContractsResponse, error)) *MockBacktestingAPIGetContractsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetExpiries mocks base method.
+func (m *MockBacktestingAPI) GetExpiries(ctx context.Context, req growwapi.GetExpiriesRequest) (growwapi.GetExpiriesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpiries", ctx, req)
+	ret0, _ := ret[0].(growwapi.GetExpiriesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExpiries indicates an expected call of GetExpiries.
+func (mr *MockBacktestingAPIMockRecorder) GetExpiries(ctx, req any) *MockBacktestingAPIGetExpiriesCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpiries", reflect.TypeOf((*MockBacktestingAPI)(nil).GetExpiries), ctx, req)
+	return &MockBacktestingAPIGetExpiriesCall{Call: call}
+}
+
+// MockBacktestingAPIGetExpiriesCall wrap *gomock.Call
+type MockBacktestingAPIGetExpiriesCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockBacktestingAPIGetExpiriesCall) Return(arg0 growwapi.GetExpiriesResponse, arg1 error) *MockBacktestingAPIGetExpiriesCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockBacktestingAPIGetExpiriesCall) Do(f func(context.Context, growwapi.GetExpiriesRequest) (growwapi.GetExpiriesResponse, error)) *MockBacktestingAPIGetExpiriesCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockBacktestingAPIGetExpiriesCall) DoAndReturn(f func(context.Context, growwapi.GetExpiriesRequest) (growwapi.GetExpiriesResponse, error)) *MockBacktestingAPIGetExpiriesCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetHistoricalCandles mocks base method.
+func (m *MockBacktestingAPI) GetHistoricalCandles(ctx context.Context, req growwapi.GetHistoricalCandlesRequest) (growwapi.HistoricalCandlesData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHistoricalCandles", ctx, req)
+	ret0, _ := ret[0].(growwapi.HistoricalCandlesData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHistoricalCandles indicates an expected call of GetHistoricalCandles.
+func (mr *MockBacktestingAPIMockRecorder) GetHistoricalCandles(ctx, req any) *MockBacktestingAPIGetHistoricalCandlesCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistoricalCandles", reflect.TypeOf((*MockBacktestingAPI)(nil).GetHistoricalCandles), ctx, req)
+	return &MockBacktestingAPIGetHistoricalCandlesCall{Call: call}
+}
+
+// MockBacktestingAPIGetHistoricalCandlesCall wrap *gomock.Call
+type MockBacktestingAPIGetHistoricalCandlesCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockBacktestingAPIGetHistoricalCandlesCall) Return(arg0 growwapi.HistoricalCandlesData, arg1 error) *MockBacktestingAPIGetHistoricalCandlesCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockBacktestingAPIGetHistoricalCandlesCall) Do(f func(context.Context, growwapi.GetHistoricalCandlesRequest) (growwapi.HistoricalCandlesData, error)) *MockBacktestingAPIGetHistoricalCandlesCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockBacktestingAPIGetHistoricalCandlesCall) DoAndReturn(f func(context.Context, growwapi.GetHistoricalCandlesRequest) (growwapi.HistoricalCandlesData, error)) *MockBacktestingAPIGetHistoricalCandlesCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockInstrumentsAPI is a mock of InstrumentsAPI interface.
+type MockInstrumentsAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockInstrumentsAPIMockRecorder
+	isgomock struct{}
+}
+
+// MockInstrumentsAPIMockRecorder is the mock recorder for MockInstrumentsAPI.
+type MockInstrumentsAPIMockRecorder struct {
+	mock *MockInstrumentsAPI
+}
+
+// NewMockInstrumentsAPI creates a new mock instance.
+func NewMockInstrumentsAPI(ctrl *gomock.Controller) *MockInstrumentsAPI {
+	mock := &MockInstrumentsAPI{ctrl: ctrl}
+	mock.recorder = &MockInstrumentsAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInstrumentsAPI) EXPECT() *MockInstrumentsAPIMockRecorder {
+	return m.recorder
+}
+
+// Instruments mocks base method.
+func (m *MockInstrumentsAPI) Instruments(ctx context.Context) ([]growwapi.Instrument, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Instruments", ctx)
+	ret0, _ := ret[0].([]growwapi.Instrument)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Instruments indicates an expected call of Instruments.
+func (mr *MockInstrumentsAPIMockRecorder) Instruments(ctx any) *MockInstrumentsAPIInstrumentsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Instruments", reflect.TypeOf((*MockInstrumentsAPI)(nil).Instruments), ctx)
+	return &MockInstrumentsAPIInstrumentsCall{Call: call}
+}
+
+// MockInstrumentsAPIInstrumentsCall wrap *gomock.Call
+type MockInstrumentsAPIInstrumentsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockInstrumentsAPIInstrumentsCall) Return(arg0 []growwapi.Instrument, arg1 error) *MockInstrumentsAPIInstrumentsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockInstrumentsAPIInstrumentsCall) Do(f func(context.Context) ([]growwapi.Instrument, error)) *MockInstrumentsAPIInstrumentsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockInstrumentsAPIInstrumentsCall) DoAndReturn(f func(context.Context) ([]growwapi.Instrument, error)) *MockInstrumentsAPIInstrumentsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockQuoteSource is a mock of QuoteSource interface.
+type MockQuoteSource struct {
+	ctrl     *gomock.Controller
+	recorder *MockQuoteSourceMockRecorder
+	isgomock struct{}
+}
+
+// MockQuoteSourceMockRecorder is the mock recorder for MockQuoteSource.
+type MockQuoteSourceMockRecorder struct {
+	mock *MockQuoteSource
+}
+
+// NewMockQuoteSource creates a new mock instance.
+func NewMockQuoteSource(ctrl *gomock.Controller) *MockQuoteSource {
+	mock := &MockQuoteSource{ctrl: ctrl}
+	mock.recorder = &MockQuoteSourceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockQuoteSource) EXPECT() *MockQuoteSourceMockRecorder {
+	return m.recorder
+}
+
+// GetQuote mocks base method.
+func (m *MockQuoteSource) GetQuote(ctx context.Context, req growwapi.QuoteRequest) (growwapi.Quote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQuote", ctx, req)
+	ret0, _ := ret[0].(growwapi.Quote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQuote indicates an expected call of GetQuote.
+func (mr *MockQuoteSourceMockRecorder) GetQuote(ctx, req any) *MockQuoteSourceGetQuoteCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuote", reflect.TypeOf((*MockQuoteSource)(nil).GetQuote), ctx, req)
+	return &MockQuoteSourceGetQuoteCall{Call: call}
+}
+
+// MockQuoteSourceGetQuoteCall wrap *gomock.Call
+type MockQuoteSourceGetQuoteCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockQuoteSourceGetQuoteCall) Return(arg0 growwapi.Quote, arg1 error) *MockQuoteSourceGetQuoteCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockQuoteSourceGetQuoteCall) Do(f func(context.Context, growwapi.QuoteRequest) (growwapi.Quote, error)) *MockQuoteSourceGetQuoteCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockQuoteSourceGetQuoteCall) DoAndReturn(f func(context.Context, growwapi.QuoteRequest) (growwapi.Quote, error)) *MockQuoteSourceGetQuoteCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
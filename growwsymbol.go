@@ -4,22 +4,30 @@ package growwapi
 
 import (
 	"fmt"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 )
 
-type (
-	growwSymbolGenerator byte
-	optionType           string
-)
+type growwSymbolGenerator byte
+
+// OptionType is the type of option contract
+type OptionType string
 
 const (
 	// GrowwSymbol can be used to generate groww symbol
 	GrowwSymbol growwSymbolGenerator = 0
 
-	OptionTypeCE optionType = "CE"
-	OptionTypePE optionType = "PE"
+	// OptionTypeCE - Call option
+	OptionTypeCE OptionType = "CE"
+	// OptionTypePE - Put option
+	OptionTypePE OptionType = "PE"
 )
 
+// growwSymbolExpiryLayout is the layout of expiry dates in groww symbols, e.g. 24Apr25
+const growwSymbolExpiryLayout = "02Jan06"
+
 func (g growwSymbolGenerator) Equity(exchange Exchange, tradingSymbol string) string {
 	return fmt.Sprintf("%s-%s", exchange, tradingSymbol)
 }
@@ -29,7 +37,7 @@ func (g growwSymbolGenerator) Index(exchange Exchange, tradingSymbol string) str
 }
 
 func (g growwSymbolGenerator) Future(exchange Exchange, tradingSymbol string, expiry time.Time) string {
-	return fmt.Sprintf("%s-%s-%s-FUT", exchange, tradingSymbol, expiry.Format("02Jan06"))
+	return fmt.Sprintf("%s-%s-%s-FUT", exchange, tradingSymbol, expiry.Format(growwSymbolExpiryLayout))
 }
 
 func (g growwSymbolGenerator) Option(
@@ -37,13 +45,13 @@ func (g growwSymbolGenerator) Option(
 	tradingSymbol string,
 	expiry time.Time,
 	strikePrice float32,
-	optionType optionType,
+	optionType OptionType,
 ) string {
 	return fmt.Sprintf(
 		"%s-%s-%s-%v-%s",
 		exchange,
 		tradingSymbol,
-		expiry.Format("02Jan06"),
+		expiry.Format(growwSymbolExpiryLayout),
 		strikePrice,
 		optionType,
 	)
@@ -66,3 +74,91 @@ func (g growwSymbolGenerator) PutOption(
 ) string {
 	return g.Option(exchange, tradingSymbol, expiry, strikePrice, OptionTypePE)
 }
+
+// GrowwSymbolParts represents the components of a groww symbol, as returned by ParseGrowwSymbol
+type GrowwSymbolParts struct {
+	// The exchange where the instrument is traded
+	Exchange Exchange
+	// Trading symbol of the instrument for equities and indices, or of the underlying for derivatives
+	Underlying string
+	// InstrumentTypeEquity, InstrumentTypeFutures, InstrumentTypeCallOption or InstrumentTypePutOption.
+	// Equities and indices share the same format, hence both are parsed as InstrumentTypeEquity
+	InstrumentType InstrumentType
+	// Expiry date of derivatives, in IST. Zero for equities and indices
+	Expiry time.Time
+	// Strike price of options, which may be fractional. Zero for others
+	StrikePrice float32
+	// Option type of options. Empty for others
+	OptionType OptionType
+}
+
+// GrowwSymbol generates the groww symbol back from its parts
+func (p GrowwSymbolParts) GrowwSymbol() string {
+	switch p.InstrumentType {
+	case InstrumentTypeFutures:
+		return GrowwSymbol.Future(p.Exchange, p.Underlying, p.Expiry)
+	case InstrumentTypeCallOption, InstrumentTypePutOption:
+		return GrowwSymbol.Option(p.Exchange, p.Underlying, p.Expiry, p.StrikePrice, p.OptionType)
+	case InstrumentTypeIndex:
+		return GrowwSymbol.Index(p.Exchange, p.Underlying)
+	default:
+		return GrowwSymbol.Equity(p.Exchange, p.Underlying)
+	}
+}
+
+// ParseGrowwSymbol decomposes a groww symbol into its parts. It is the inverse of the GrowwSymbol generator,
+// so ParseGrowwSymbol(s).GrowwSymbol() == s for every symbol generated by it.
+//
+// Trading symbols may contain hyphens themselves (e.g. NSE-BAJAJ-AUTO), hence the symbol is parsed from the right.
+func ParseGrowwSymbol(symbol string) (GrowwSymbolParts, error) {
+	parts := strings.Split(symbol, "-")
+	if len(parts) < 2 || parts[0] == "" {
+		return GrowwSymbolParts{}, fmt.Errorf("invalid groww symbol %q: expected EXCHANGE-SYMBOL", symbol)
+	}
+
+	if slices.Contains(parts[1:], "") {
+		return GrowwSymbolParts{}, fmt.Errorf("invalid groww symbol %q: empty component", symbol)
+	}
+
+	out := GrowwSymbolParts{Exchange: Exchange(parts[0])}
+	last := parts[len(parts)-1]
+
+	switch {
+	case last == string(InstrumentTypeFutures) && len(parts) >= 4:
+		expiry, err := time.ParseInLocation(growwSymbolExpiryLayout, parts[len(parts)-2], IST)
+		if err != nil {
+			return GrowwSymbolParts{}, fmt.Errorf("invalid groww symbol %q: expiry: %w", symbol, err)
+		}
+
+		out.InstrumentType = InstrumentTypeFutures
+		out.Underlying = strings.Join(parts[1:len(parts)-2], "-")
+		out.Expiry = expiry
+
+	case (last == string(OptionTypeCE) || last == string(OptionTypePE)) && len(parts) >= 5:
+		expiry, err := time.ParseInLocation(growwSymbolExpiryLayout, parts[len(parts)-3], IST)
+		if err != nil {
+			return GrowwSymbolParts{}, fmt.Errorf("invalid groww symbol %q: expiry: %w", symbol, err)
+		}
+
+		strike, err := strconv.ParseFloat(parts[len(parts)-2], 32)
+		if err != nil {
+			return GrowwSymbolParts{}, fmt.Errorf("invalid groww symbol %q: strike price: %w", symbol, err)
+		}
+
+		out.InstrumentType = InstrumentType(last)
+		out.Underlying = strings.Join(parts[1:len(parts)-3], "-")
+		out.Expiry = expiry
+		out.StrikePrice = float32(strike)
+		out.OptionType = OptionType(last)
+
+	default:
+		out.InstrumentType = InstrumentTypeEquity
+		out.Underlying = strings.Join(parts[1:], "-")
+	}
+
+	if out.Underlying == "" {
+		return GrowwSymbolParts{}, fmt.Errorf("invalid groww symbol %q: missing symbol", symbol)
+	}
+
+	return out, nil
+}
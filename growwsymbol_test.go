@@ -0,0 +1,142 @@
+package growwapi
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseGrowwSymbol(t *testing.T) {
+	expiry := time.Date(2025, time.April, 24, 0, 0, 0, 0, IST)
+
+	tests := []struct {
+		symbol string
+		want   GrowwSymbolParts
+	}{
+		{
+			symbol: "NSE-RELIANCE",
+			want:   GrowwSymbolParts{Exchange: ExchangeNse, Underlying: "RELIANCE", InstrumentType: InstrumentTypeEquity},
+		},
+		{
+			symbol: "NSE-BAJAJ-AUTO",
+			want:   GrowwSymbolParts{Exchange: ExchangeNse, Underlying: "BAJAJ-AUTO", InstrumentType: InstrumentTypeEquity},
+		},
+		{
+			symbol: "NSE-NIFTY-24Apr25-FUT",
+			want: GrowwSymbolParts{
+				Exchange:       ExchangeNse,
+				Underlying:     "NIFTY",
+				InstrumentType: InstrumentTypeFutures,
+				Expiry:         expiry,
+			},
+		},
+		{
+			symbol: "NSE-BAJAJ-AUTO-24Apr25-FUT",
+			want: GrowwSymbolParts{
+				Exchange:       ExchangeNse,
+				Underlying:     "BAJAJ-AUTO",
+				InstrumentType: InstrumentTypeFutures,
+				Expiry:         expiry,
+			},
+		},
+		{
+			symbol: "NSE-NIFTY-24Apr25-22500-CE",
+			want: GrowwSymbolParts{
+				Exchange:       ExchangeNse,
+				Underlying:     "NIFTY",
+				InstrumentType: InstrumentTypeCallOption,
+				Expiry:         expiry,
+				StrikePrice:    22500,
+				OptionType:     OptionTypeCE,
+			},
+		},
+		{
+			symbol: "NSE-BAJAJ-AUTO-24Apr25-8250.5-PE",
+			want: GrowwSymbolParts{
+				Exchange:       ExchangeNse,
+				Underlying:     "BAJAJ-AUTO",
+				InstrumentType: InstrumentTypePutOption,
+				Expiry:         expiry,
+				StrikePrice:    8250.5,
+				OptionType:     OptionTypePE,
+			},
+		},
+		{
+			// too short to be a future, hence the suffix is part of the trading symbol
+			symbol: "NSE-XYZ-FUT",
+			want:   GrowwSymbolParts{Exchange: ExchangeNse, Underlying: "XYZ-FUT", InstrumentType: InstrumentTypeEquity},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.symbol, func(t *testing.T) {
+			got, err := ParseGrowwSymbol(tt.symbol)
+			if err != nil {
+				t.Fatalf("ParseGrowwSymbol() error = %v", err)
+			}
+
+			if !got.Expiry.Equal(tt.want.Expiry) {
+				t.Errorf("Expiry = %v, want %v", got.Expiry, tt.want.Expiry)
+			}
+
+			got.Expiry, tt.want.Expiry = time.Time{}, time.Time{}
+			if got != tt.want {
+				t.Errorf("ParseGrowwSymbol() = %+v, want %+v", got, tt.want)
+			}
+
+			if symbol := got.GrowwSymbol(); tt.want.InstrumentType == InstrumentTypeEquity && symbol != tt.symbol {
+				t.Errorf("GrowwSymbol() = %q, want %q", symbol, tt.symbol)
+			}
+		})
+	}
+}
+
+func TestParseGrowwSymbolInvalid(t *testing.T) {
+	tests := []string{
+		"",
+		"NSE",
+		"-RELIANCE",
+		"NSE-",
+		"NSE-NIFTY-31Feb25-FUT",
+		"NSE-NIFTY-2025-04-24-FUT",
+		"NSE-NIFTY-24Apr25-abc-CE",
+		"NSE-BAJAJ--AUTO",
+		"NSE-NIFTY-24Apr25-FUT-",
+	}
+
+	for _, symbol := range tests {
+		t.Run(symbol, func(t *testing.T) {
+			if got, err := ParseGrowwSymbol(symbol); err == nil {
+				t.Errorf("ParseGrowwSymbol() = %+v, want error", got)
+			}
+		})
+	}
+}
+
+func TestGrowwSymbolRoundTrip(t *testing.T) {
+	expiry := time.Date(2025, time.December, 30, 0, 0, 0, 0, IST)
+
+	tests := []string{
+		GrowwSymbol.Equity(ExchangeNse, "RELIANCE"),
+		GrowwSymbol.Equity(ExchangeBse, "BAJAJ-AUTO"),
+		GrowwSymbol.Index(ExchangeNse, "NIFTY"),
+		GrowwSymbol.Future(ExchangeNse, "BANKNIFTY", expiry),
+		GrowwSymbol.Future(ExchangeNse, "BAJAJ-AUTO", expiry),
+		GrowwSymbol.CallOption(ExchangeNse, "NIFTY", expiry, 26000),
+		GrowwSymbol.PutOption(ExchangeNse, "NIFTY", expiry, 25950.5),
+		GrowwSymbol.CallOption(ExchangeNse, "M-M", expiry, 3400),
+		GrowwSymbol.PutOption(ExchangeBse, "SENSEX", expiry, 0.25),
+	}
+
+	for _, symbol := range tests {
+		t.Run(symbol, func(t *testing.T) {
+			parts, err := ParseGrowwSymbol(symbol)
+			if err != nil {
+				t.Fatalf("ParseGrowwSymbol() error = %v", err)
+			}
+
+			if got := parts.GrowwSymbol(); got != symbol {
+				t.Errorf("GrowwSymbol() = %q, want %q", got, symbol)
+			}
+		})
+	}
+}
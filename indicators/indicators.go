@@ -0,0 +1,47 @@
+// Package indicators computes technical indicators on growwapi.Candle series.
+//
+// Every indicator is a type with an Update method, which takes the next candle and returns the value of the
+// indicator at that candle, and false while the indicator has not seen enough candles yet. Indicators can be updated
+// as new candles arrive, or computed over a whole series with Compute.
+//
+// Prices are converted to float64 for computations. Indicators are not safe for concurrent use.
+package indicators
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rctrj/growwapi-go"
+)
+
+// Indicator is an indicator which is updated with a candle at a time
+type Indicator[T any] interface {
+	// Update feeds the next candle to the indicator, and returns the value at that candle.
+	// False is returned while the indicator is warming up.
+	Update(candle growwapi.Candle) (T, bool)
+}
+
+// Point represents the value of an indicator at the timestamp of a candle
+type Point[T any] struct {
+	Timestamp time.Time
+	Value     T
+}
+
+// Compute feeds candles, sorted by timestamp, to indicator and returns its values at the candles it had one for.
+// The first points are missing while the indicator warms up, so points are identified by their Timestamp.
+func Compute[T any](indicator Indicator[T], candles []growwapi.Candle) []Point[T] {
+	out := make([]Point[T], 0, len(candles))
+	for _, candle := range candles {
+		if value, ok := indicator.Update(candle); ok {
+			out = append(out, Point[T]{Timestamp: candle.Timestamp.Time, Value: value})
+		}
+	}
+
+	return out
+}
+
+func mustPositive(name string, period int) {
+	if period <= 0 {
+		panic(fmt.Sprintf("indicators: non-positive %s period %d", name, period))
+	}
+}
@@ -0,0 +1,152 @@
+package indicators
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/rctrj/growwapi-go"
+)
+
+var start = time.Date(2024, time.January, 1, 9, 15, 0, 0, growwapi.IST)
+
+// closeCandles returns daily candles closing at closes, with the high and the low at the close
+func closeCandles(closes ...float64) []growwapi.Candle {
+	return ohlcCandles(closes, closes, closes)
+}
+
+// ohlcCandles returns daily candles with given highs, lows and closes, opening at the close
+func ohlcCandles(highs, lows, closes []float64) []growwapi.Candle {
+	out := make([]growwapi.Candle, len(closes))
+	for i := range closes {
+		out[i] = growwapi.Candle{
+			Timestamp: growwapi.Time{Time: start.AddDate(0, 0, i)},
+			Ohlcv: growwapi.Ohlcv{
+				Ohlc: growwapi.Ohlc{
+					Open:  float32(closes[i]),
+					High:  float32(highs[i]),
+					Low:   float32(lows[i]),
+					Close: float32(closes[i]),
+				},
+			},
+		}
+	}
+
+	return out
+}
+
+// values returns the values of points
+func values[T any](points []Point[T]) []T {
+	out := make([]T, len(points))
+	for i, point := range points {
+		out[i] = point.Value
+	}
+
+	return out
+}
+
+// assertClose fails the test unless got and want have the same length and differ by at most tolerance at every index
+func assertClose(t *testing.T, got, want []float64, tolerance float64) {
+	t.Helper()
+
+	if len(got) != len(want) {
+		t.Fatalf("got %d values, want %d", len(got), len(want))
+	}
+
+	for i := range want {
+		if math.Abs(got[i]-want[i]) > tolerance {
+			t.Errorf("value %d = %.4f, want %.4f ± %v", i, got[i], want[i], tolerance)
+		}
+	}
+}
+
+// randomCandles returns n five minute candles over several days, following a deterministic random walk
+func randomCandles(n int) []growwapi.Candle {
+	r := rand.New(rand.NewPCG(1, 2))
+
+	out := make([]growwapi.Candle, n)
+	price := 100.0
+	for i := range out {
+		// 75 candles make a session from 09:15 to 15:30
+		timestamp := start.AddDate(0, 0, i/75).Add(time.Duration(i%75) * 5 * time.Minute)
+
+		open := price
+		price = max(price+r.NormFloat64(), 1)
+		high := max(open, price) + r.Float64()
+		low := min(open, price) - r.Float64()
+
+		out[i] = growwapi.Candle{
+			Timestamp: growwapi.Time{Time: timestamp},
+			Ohlcv: growwapi.Ohlcv{
+				Ohlc:   growwapi.Ohlc{Open: float32(open), High: float32(high), Low: float32(low), Close: float32(price)},
+				Volume: float32(r.IntN(1000)),
+			},
+		}
+	}
+
+	return out
+}
+
+func TestComputeMatchesUpdate(t *testing.T) {
+	candles := randomCandles(500)
+
+	t.Run("SMA", func(t *testing.T) {
+		testComputeMatchesUpdate(t, func() Indicator[float64] { return NewSMA(20) }, candles)
+	})
+	t.Run("EMA", func(t *testing.T) {
+		testComputeMatchesUpdate(t, func() Indicator[float64] { return NewEMA(20) }, candles)
+	})
+	t.Run("RSI", func(t *testing.T) {
+		testComputeMatchesUpdate(t, func() Indicator[float64] { return NewRSI(14) }, candles)
+	})
+	t.Run("MACD", func(t *testing.T) {
+		testComputeMatchesUpdate(t, func() Indicator[MACDValue] { return NewMACD(12, 26, 9) }, candles)
+	})
+	t.Run("Bollinger", func(t *testing.T) {
+		testComputeMatchesUpdate(t, func() Indicator[BollingerValue] { return NewBollinger(20, 2) }, candles)
+	})
+	t.Run("ATR", func(t *testing.T) {
+		testComputeMatchesUpdate(t, func() Indicator[float64] { return NewATR(14) }, candles)
+	})
+	t.Run("SuperTrend", func(t *testing.T) {
+		testComputeMatchesUpdate(t, func() Indicator[SuperTrendValue] { return NewSuperTrend(10, 3) }, candles)
+	})
+	t.Run("VWAP", func(t *testing.T) {
+		testComputeMatchesUpdate(t, func() Indicator[float64] { return NewVWAP() }, candles)
+	})
+	t.Run("OBV", func(t *testing.T) {
+		testComputeMatchesUpdate(t, func() Indicator[float64] { return NewOBV() }, candles)
+	})
+}
+
+// testComputeMatchesUpdate checks that Compute over candles returns what updating an indicator candle by candle
+// does, and that computing over a prefix of candles returns a prefix of the points, i.e. that no value depends on a
+// later candle
+func testComputeMatchesUpdate[T comparable](t *testing.T, newIndicator func() Indicator[T], candles []growwapi.Candle) {
+	points := Compute(newIndicator(), candles)
+
+	var streamed []Point[T]
+	indicator := newIndicator()
+	for _, candle := range candles {
+		if value, ok := indicator.Update(candle); ok {
+			streamed = append(streamed, Point[T]{Timestamp: candle.Timestamp.Time, Value: value})
+		}
+	}
+
+	if len(points) == 0 {
+		t.Fatal("Compute() returned no points")
+	}
+
+	if !slices.Equal(points, streamed) {
+		t.Fatalf("Compute() differs from Update()")
+	}
+
+	for _, n := range []int{1, 33, 75, 76, 250} {
+		prefix := Compute(newIndicator(), candles[:n])
+		if !slices.Equal(prefix, points[:len(prefix)]) {
+			t.Errorf("Compute() over %d candles is not a prefix of Compute() over all of them", n)
+		}
+	}
+}
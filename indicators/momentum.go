@@ -0,0 +1,91 @@
+package indicators
+
+import (
+	"github.com/rctrj/growwapi-go"
+)
+
+// RSI is the relative strength index of closing prices over a period, with gains and losses smoothed the way
+// Welles Wilder defined it. Values range from 0 to 100.
+type RSI struct {
+	gains, losses wilder
+	prev          float64
+	started       bool
+}
+
+// NewRSI creates an RSI over period candles, usually 14. It panics if period is not positive.
+func NewRSI(period int) *RSI {
+	mustPositive("RSI", period)
+	return &RSI{gains: newWilder(period), losses: newWilder(period)}
+}
+
+// Update implements Indicator. The first value is at the (period+1)-th candle, as it takes period price changes.
+func (r *RSI) Update(candle growwapi.Candle) (float64, bool) {
+	price := float64(candle.Close)
+	if !r.started {
+		r.prev, r.started = price, true
+		return 0, false
+	}
+
+	change := price - r.prev
+	r.prev = price
+
+	gain, _ := r.gains.add(max(change, 0))
+	loss, ok := r.losses.add(max(-change, 0))
+	if !ok {
+		return 0, false
+	}
+
+	if loss == 0 {
+		if gain == 0 {
+			return 50, true
+		}
+
+		return 100, true
+	}
+
+	return 100 - 100/(1+gain/loss), true
+}
+
+// MACDValue represents the value of MACD at a candle
+type MACDValue struct {
+	// Fast EMA minus slow EMA
+	MACD float64
+	// EMA of MACD
+	Signal float64
+	// MACD minus Signal
+	Histogram float64
+}
+
+// MACD is the moving average convergence divergence of closing prices
+type MACD struct {
+	fast, slow, signal ema
+}
+
+// NewMACD creates a MACD with the periods of its fast, slow and signal EMAs, usually 12, 26 and 9.
+// It panics if a period is not positive.
+func NewMACD(fast, slow, signal int) *MACD {
+	mustPositive("MACD fast", fast)
+	mustPositive("MACD slow", slow)
+	mustPositive("MACD signal", signal)
+	return &MACD{fast: newEMA(fast), slow: newEMA(slow), signal: newEMA(signal)}
+}
+
+// Update implements Indicator. The first value is once the signal EMA has seen signal values of MACD,
+// at the (max(fast, slow)+signal-1)-th candle.
+func (m *MACD) Update(candle growwapi.Candle) (MACDValue, bool) {
+	price := float64(candle.Close)
+
+	fast, fastOk := m.fast.add(price)
+	slow, slowOk := m.slow.add(price)
+	if !fastOk || !slowOk {
+		return MACDValue{}, false
+	}
+
+	macd := fast - slow
+	signal, ok := m.signal.add(macd)
+	if !ok {
+		return MACDValue{}, false
+	}
+
+	return MACDValue{MACD: macd, Signal: signal, Histogram: macd - signal}, true
+}
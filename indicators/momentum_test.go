@@ -0,0 +1,72 @@
+package indicators
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRSI(t *testing.T) {
+	// price series of the StockCharts ChartSchool spreadsheet on RSI
+	closes := []float64{
+		44.34, 44.09, 44.15, 43.61, 44.33, 44.83, 45.10, 45.42, 45.84, 46.08, 45.89,
+		46.03, 45.61, 46.28, 46.28, 46.00, 46.03, 46.41, 46.22, 45.64, 46.21, 46.25,
+		45.71, 46.45, 45.78, 45.35, 44.03, 44.18, 44.22, 44.57, 43.42, 42.66, 43.13,
+	}
+
+	// 14-day RSI as published by StockCharts. The spreadsheet rounds the first average gain and loss to 2 decimals,
+	// which moves the values by less than 0.1.
+	want := []float64{
+		70.53, 66.32, 66.55, 69.41, 66.36, 57.97, 62.93, 63.26, 56.06, 62.38,
+		54.71, 50.42, 39.99, 41.46, 41.87, 45.46, 37.30, 33.08, 37.77,
+	}
+
+	points := Compute(NewRSI(14), closeCandles(closes...))
+	assertClose(t, values(points), want, 0.1)
+
+	if got, want := points[0].Timestamp, start.AddDate(0, 0, 14); !got.Equal(want) {
+		t.Errorf("first Timestamp = %v, want %v", got, want)
+	}
+}
+
+func TestRSIWithoutLosses(t *testing.T) {
+	tests := []struct {
+		name   string
+		closes []float64
+		want   float64
+	}{
+		{name: "flat", closes: []float64{10, 10, 10, 10}, want: 50},
+		{name: "rising", closes: []float64{10, 11, 12, 13}, want: 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertClose(t, values(Compute(NewRSI(3), closeCandles(tt.closes...))), []float64{tt.want}, 0)
+		})
+	}
+}
+
+func TestMACD(t *testing.T) {
+	// An EMA seeded with the SMA lags a linear series by exactly (period-1)/2, since the SMA of the first period
+	// values does and every update keeps the lag: alpha*(1+(period-1)/2) is 1. On a series rising by 1 per candle,
+	// MACD(12, 26, 9) is hence (26-1)/2-(12-1)/2 = 7 from the 26th candle, and so is its signal from the 34th.
+	closes := make([]float64, 60)
+	for i := range closes {
+		closes[i] = 100 + float64(i)
+	}
+
+	points := Compute(NewMACD(12, 26, 9), closeCandles(closes...))
+	if len(points) != len(closes)-33 {
+		t.Fatalf("got %d points, want %d", len(points), len(closes)-33)
+	}
+
+	if got, want := points[0].Timestamp, start.AddDate(0, 0, 33); !got.Equal(want) {
+		t.Errorf("first Timestamp = %v, want %v", got, want)
+	}
+
+	for i, point := range points {
+		value := point.Value
+		if math.Abs(value.MACD-7) > 1e-9 || math.Abs(value.Signal-7) > 1e-9 || math.Abs(value.Histogram) > 1e-9 {
+			t.Errorf("value %d = %+v, want MACD and Signal 7, Histogram 0", i, value)
+		}
+	}
+}
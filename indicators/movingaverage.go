@@ -0,0 +1,99 @@
+package indicators
+
+import (
+	"github.com/rctrj/growwapi-go"
+)
+
+// SMA is the simple moving average of closing prices over a period
+type SMA struct {
+	sma sma
+}
+
+// NewSMA creates an SMA over period candles. It panics if period is not positive.
+func NewSMA(period int) *SMA {
+	mustPositive("SMA", period)
+	return &SMA{sma: newSMA(period)}
+}
+
+// Update implements Indicator. The first value is at the period-th candle.
+func (s *SMA) Update(candle growwapi.Candle) (float64, bool) {
+	return s.sma.add(float64(candle.Close))
+}
+
+// EMA is the exponential moving average of closing prices over a period, with a smoothing factor of 2/(period+1).
+// It is seeded with the simple moving average of the first period candles.
+type EMA struct {
+	ema ema
+}
+
+// NewEMA creates an EMA over period candles. It panics if period is not positive.
+func NewEMA(period int) *EMA {
+	mustPositive("EMA", period)
+	return &EMA{ema: newEMA(period)}
+}
+
+// Update implements Indicator. The first value is at the period-th candle.
+func (e *EMA) Update(candle growwapi.Candle) (float64, bool) {
+	return e.ema.add(float64(candle.Close))
+}
+
+// sma is a simple moving average of any values
+type sma struct {
+	window []float64
+	next   int
+	count  int
+	sum    float64
+}
+
+func newSMA(period int) sma {
+	return sma{window: make([]float64, period)}
+}
+
+func (s *sma) add(value float64) (float64, bool) {
+	s.sum += value - s.window[s.next]
+	s.window[s.next] = value
+	s.next = (s.next + 1) % len(s.window)
+	s.count = min(s.count+1, len(s.window))
+
+	if s.count < len(s.window) {
+		return 0, false
+	}
+
+	return s.sum / float64(len(s.window)), true
+}
+
+// values returns the values in the window, oldest first
+func (s *sma) values() []float64 {
+	return append(s.window[s.next:len(s.window):len(s.window)], s.window[:s.next]...)
+}
+
+// ema is an exponential moving average of any values, seeded with their simple moving average
+type ema struct {
+	seed  sma
+	alpha float64
+	value float64
+	ready bool
+}
+
+func newEMA(period int) ema {
+	return ema{seed: newSMA(period), alpha: 2 / float64(period+1)}
+}
+
+func (e *ema) add(value float64) (float64, bool) {
+	if !e.ready {
+		e.value, e.ready = e.seed.add(value)
+		return e.value, e.ready
+	}
+
+	e.value += e.alpha * (value - e.value)
+	return e.value, true
+}
+
+// wilder is the smoothed average of Welles Wilder, an ema with a smoothing factor of 1/period
+type wilder struct {
+	ema
+}
+
+func newWilder(period int) wilder {
+	return wilder{ema{seed: newSMA(period), alpha: 1 / float64(period)}}
+}
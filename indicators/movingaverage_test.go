@@ -0,0 +1,37 @@
+package indicators
+
+import (
+	"testing"
+)
+
+// stockChartsMovingAverageCloses is the price series of the StockCharts ChartSchool spreadsheet on moving averages
+var stockChartsMovingAverageCloses = []float64{
+	22.27, 22.19, 22.08, 22.17, 22.18, 22.13, 22.23, 22.43, 22.24, 22.29,
+	22.15, 22.39, 22.38, 22.61, 23.36, 24.05, 23.75, 23.83, 23.95, 23.63,
+	23.82, 23.87, 23.65, 23.19, 23.10, 23.33, 22.68, 23.10, 22.40, 22.17,
+}
+
+func TestSMA(t *testing.T) {
+	// 10-day SMA as published by StockCharts, rounded to 2 decimals
+	want := []float64{
+		22.22, 22.21, 22.23, 22.26, 22.31, 22.42, 22.61, 22.77, 22.91, 23.08, 23.21,
+		23.38, 23.53, 23.65, 23.71, 23.69, 23.61, 23.51, 23.43, 23.28, 23.13,
+	}
+
+	points := Compute(NewSMA(10), closeCandles(stockChartsMovingAverageCloses...))
+	assertClose(t, values(points), want, 0.01)
+
+	if got, want := points[0].Timestamp, start.AddDate(0, 0, 9); !got.Equal(want) {
+		t.Errorf("first Timestamp = %v, want %v", got, want)
+	}
+}
+
+func TestEMA(t *testing.T) {
+	// 10-day EMA as published by StockCharts, rounded to 2 decimals
+	want := []float64{
+		22.22, 22.21, 22.24, 22.27, 22.33, 22.52, 22.80, 22.97, 23.13, 23.28, 23.34,
+		23.43, 23.51, 23.54, 23.47, 23.40, 23.39, 23.26, 23.23, 23.08, 22.92,
+	}
+
+	assertClose(t, values(Compute(NewEMA(10), closeCandles(stockChartsMovingAverageCloses...))), want, 0.01)
+}
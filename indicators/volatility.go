@@ -0,0 +1,144 @@
+package indicators
+
+import (
+	"math"
+
+	"github.com/rctrj/growwapi-go"
+)
+
+// BollingerValue represents the value of Bollinger Bands at a candle
+type BollingerValue struct {
+	// Middle plus the multiple of the standard deviation
+	Upper float64
+	// Simple moving average
+	Middle float64
+	// Middle minus the multiple of the standard deviation
+	Lower float64
+}
+
+// Bollinger is the Bollinger Bands of closing prices: their simple moving average, and bands a multiple of their
+// population standard deviation away from it
+type Bollinger struct {
+	sma        sma
+	multiplier float64
+}
+
+// NewBollinger creates Bollinger Bands over period candles, with the bands multiplier standard deviations away from
+// the average, usually 20 and 2. It panics if period is not positive.
+func NewBollinger(period int, multiplier float64) *Bollinger {
+	mustPositive("Bollinger", period)
+	return &Bollinger{sma: newSMA(period), multiplier: multiplier}
+}
+
+// Update implements Indicator. The first value is at the period-th candle.
+func (b *Bollinger) Update(candle growwapi.Candle) (BollingerValue, bool) {
+	mean, ok := b.sma.add(float64(candle.Close))
+	if !ok {
+		return BollingerValue{}, false
+	}
+
+	var variance float64
+	values := b.sma.values()
+	for _, value := range values {
+		variance += (value - mean) * (value - mean)
+	}
+
+	width := b.multiplier * math.Sqrt(variance/float64(len(values)))
+	return BollingerValue{Upper: mean + width, Middle: mean, Lower: mean - width}, true
+}
+
+// ATR is the average true range over a period, smoothed the way Welles Wilder defined it.
+// The true range of the first candle is its high minus its low.
+type ATR struct {
+	average   wilder
+	prevClose float64
+	started   bool
+}
+
+// NewATR creates an ATR over period candles, usually 14. It panics if period is not positive.
+func NewATR(period int) *ATR {
+	mustPositive("ATR", period)
+	return &ATR{average: newWilder(period)}
+}
+
+// Update implements Indicator. The first value is at the period-th candle.
+func (a *ATR) Update(candle growwapi.Candle) (float64, bool) {
+	high, low := float64(candle.High), float64(candle.Low)
+
+	trueRange := high - low
+	if a.started {
+		trueRange = max(trueRange, math.Abs(high-a.prevClose), math.Abs(low-a.prevClose))
+	}
+
+	a.prevClose, a.started = float64(candle.Close), true
+	return a.average.add(trueRange)
+}
+
+// SuperTrendValue represents the value of SuperTrend at a candle
+type SuperTrendValue struct {
+	// The lower band in an uptrend, the upper band in a downtrend
+	Value float64
+	// Whether the trend is up
+	Uptrend bool
+}
+
+// SuperTrend follows the trend with bands a multiple of the ATR away from the middle of the candles.
+// The trend turns down when a candle closes below the lower band, and up when one closes above the upper band.
+type SuperTrend struct {
+	atr        *ATR
+	multiplier float64
+
+	upper, lower float64
+	prevClose    float64
+	uptrend      bool
+	started      bool
+}
+
+// NewSuperTrend creates a SuperTrend with the period of its ATR and the multiplier of the bands, usually 10 and 3.
+// It panics if period is not positive.
+func NewSuperTrend(period int, multiplier float64) *SuperTrend {
+	mustPositive("SuperTrend", period)
+	return &SuperTrend{atr: NewATR(period), multiplier: multiplier}
+}
+
+// Update implements Indicator. The first value is at the period-th candle, where the trend starts up.
+func (s *SuperTrend) Update(candle growwapi.Candle) (SuperTrendValue, bool) {
+	atr, ok := s.atr.Update(candle)
+	closePrice := float64(candle.Close)
+	if !ok {
+		s.prevClose = closePrice
+		return SuperTrendValue{}, false
+	}
+
+	middle := (float64(candle.High) + float64(candle.Low)) / 2
+	upper, lower := middle+s.multiplier*atr, middle-s.multiplier*atr
+
+	if !s.started {
+		s.upper, s.lower, s.uptrend, s.started = upper, lower, true, true
+		s.prevClose = closePrice
+		return SuperTrendValue{Value: lower, Uptrend: true}, true
+	}
+
+	// bands only move towards the price while it stays within them
+	if upper < s.upper || s.prevClose > s.upper {
+		s.upper = upper
+	}
+
+	if lower > s.lower || s.prevClose < s.lower {
+		s.lower = lower
+	}
+
+	switch {
+	case s.uptrend && closePrice < s.lower:
+		s.uptrend = false
+	case !s.uptrend && closePrice > s.upper:
+		s.uptrend = true
+	}
+
+	s.prevClose = closePrice
+	if s.uptrend {
+		return SuperTrendValue{Value: s.lower, Uptrend: true}, true
+	}
+
+	return SuperTrendValue{Value: s.upper, Uptrend: false}, true
+}
@@ -0,0 +1,127 @@
+package indicators
+
+import (
+	"testing"
+)
+
+func TestBollinger(t *testing.T) {
+	// price series of the StockCharts ChartSchool spreadsheet on Bollinger Bands
+	closes := []float64{
+		86.16, 89.09, 88.78, 90.32, 89.07, 91.15, 89.44, 89.18, 86.93, 87.68, 86.96,
+		89.43, 89.32, 88.72, 87.45, 87.26, 89.50, 87.90, 89.13, 90.70, 92.90, 92.98,
+		91.80, 92.66, 92.68, 92.30, 92.77, 92.54, 92.95, 93.20, 91.07, 89.83, 89.74,
+		90.40, 90.74, 88.02, 88.09, 88.84, 90.78, 90.54, 91.39, 90.65,
+	}
+
+	// 20-day Bollinger Bands with a multiplier of 2 as published by StockCharts, rounded to 2 decimals
+	want := []BollingerValue{
+		{Middle: 88.71, Upper: 91.29, Lower: 86.12},
+		{Middle: 89.05, Upper: 91.95, Lower: 86.14},
+		{Middle: 89.24, Upper: 92.61, Lower: 85.87},
+		{Middle: 89.39, Upper: 92.93, Lower: 85.85},
+		{Middle: 89.51, Upper: 93.31, Lower: 85.70},
+		{Middle: 89.69, Upper: 93.73, Lower: 85.65},
+		{Middle: 89.75, Upper: 93.90, Lower: 85.59},
+		{Middle: 89.91, Upper: 94.26, Lower: 85.56},
+		{Middle: 90.08, Upper: 94.56, Lower: 85.60},
+		{Middle: 90.38, Upper: 94.79, Lower: 85.98},
+		{Middle: 90.66, Upper: 95.04, Lower: 86.27},
+		{Middle: 90.86, Upper: 94.91, Lower: 86.82},
+		{Middle: 90.88, Upper: 94.90, Lower: 86.87},
+		{Middle: 90.91, Upper: 94.90, Lower: 86.91},
+		{Middle: 90.99, Upper: 94.86, Lower: 87.12},
+		{Middle: 91.15, Upper: 94.67, Lower: 87.63},
+		{Middle: 91.19, Upper: 94.55, Lower: 87.83},
+		{Middle: 91.12, Upper: 94.68, Lower: 87.56},
+		{Middle: 91.17, Upper: 94.57, Lower: 87.76},
+		{Middle: 91.25, Upper: 94.53, Lower: 87.97},
+		{Middle: 91.24, Upper: 94.53, Lower: 87.95},
+		{Middle: 91.17, Upper: 94.37, Lower: 87.96},
+		{Middle: 91.05, Upper: 94.15, Lower: 87.95},
+	}
+
+	points := Compute(NewBollinger(20, 2), closeCandles(closes...))
+	if len(points) != len(want) {
+		t.Fatalf("got %d points, want %d", len(points), len(want))
+	}
+
+	var middles, uppers, lowers, wantMiddles, wantUppers, wantLowers []float64
+	for i, point := range points {
+		middles, wantMiddles = append(middles, point.Value.Middle), append(wantMiddles, want[i].Middle)
+		uppers, wantUppers = append(uppers, point.Value.Upper), append(wantUppers, want[i].Upper)
+		lowers, wantLowers = append(lowers, point.Value.Lower), append(wantLowers, want[i].Lower)
+	}
+
+	t.Run("Middle", func(t *testing.T) { assertClose(t, middles, wantMiddles, 0.01) })
+	t.Run("Upper", func(t *testing.T) { assertClose(t, uppers, wantUppers, 0.01) })
+	t.Run("Lower", func(t *testing.T) { assertClose(t, lowers, wantLowers, 0.01) })
+}
+
+func TestATR(t *testing.T) {
+	// price series of the StockCharts ChartSchool spreadsheet on ATR
+	highs := []float64{
+		48.70, 48.72, 48.90, 48.87, 48.82, 49.05, 49.20, 49.35, 49.92, 50.19, 50.12, 49.66, 49.88, 50.19, 50.36,
+		50.57, 50.65, 50.43, 49.63, 50.33, 50.29, 50.17, 49.32, 48.50, 48.32, 46.80, 47.80, 48.39, 48.66, 48.79,
+	}
+	lows := []float64{
+		47.79, 48.14, 48.39, 48.37, 48.24, 48.64, 48.94, 48.86, 49.50, 49.87, 49.20, 48.90, 49.43, 49.73, 49.26,
+		50.09, 50.30, 49.21, 48.98, 49.61, 49.20, 49.43, 48.08, 47.64, 41.55, 44.28, 47.31, 47.20, 47.90, 47.73,
+	}
+	closes := []float64{
+		48.16, 48.61, 48.75, 48.63, 48.74, 49.03, 49.07, 49.32, 49.91, 50.13, 49.53, 49.50, 49.75, 50.03, 50.31,
+		50.52, 50.41, 49.34, 49.37, 50.23, 49.24, 49.93, 48.43, 48.18, 46.57, 45.41, 47.77, 47.72, 48.62, 47.85,
+	}
+
+	// 14-day ATR as published by StockCharts. The spreadsheet rounds true ranges to 2 decimals, which moves the
+	// values by less than 0.01.
+	want := []float64{
+		0.56, 0.59, 0.59, 0.57, 0.62, 0.62, 0.64, 0.67, 0.69,
+		0.77, 0.78, 1.21, 1.30, 1.38, 1.37, 1.34, 1.32,
+	}
+
+	points := Compute(NewATR(14), ohlcCandles(highs, lows, closes))
+	assertClose(t, values(points), want, 0.01)
+
+	if got, want := points[0].Timestamp, start.AddDate(0, 0, 13); !got.Equal(want) {
+		t.Errorf("first Timestamp = %v, want %v", got, want)
+	}
+}
+
+func TestSuperTrend(t *testing.T) {
+	highs := []float64{11, 12, 13, 14, 12, 10, 14}
+	lows := []float64{9, 10, 11, 12, 8, 8, 12}
+	closes := []float64{10, 11, 12, 13, 9, 9, 13.5}
+
+	// With a period of 3 and a multiplier of 1:
+	//   candle 3: ATR 2, bands 14 and 10, the trend starts up at the lower band
+	//   candle 4: ATR 2, upper band 15 is not below 14 and kept at 14, lower band raised to 11
+	//   candle 5: true range 5, ATR (2*2+5)/3 = 3, upper band lowered to 13, the close of 9 breaks the lower band 11
+	//   candle 6: ATR (3*2+2)/3 = 8/3, upper band lowered to 9+8/3, lower band 9-8/3 reset as the close was below it
+	//   candle 7: true range 5, ATR (8/3*2+5)/3 = 31/9, the close of 13.5 breaks the upper band 9+8/3, lower band
+	//   raised to 13-31/9
+	want := []SuperTrendValue{
+		{Value: 10, Uptrend: true},
+		{Value: 11, Uptrend: true},
+		{Value: 13, Uptrend: false},
+		{Value: 9 + 8.0/3, Uptrend: false},
+		{Value: 13 - 31.0/9, Uptrend: true},
+	}
+
+	points := Compute(NewSuperTrend(3, 1), ohlcCandles(highs, lows, closes))
+	if len(points) != len(want) {
+		t.Fatalf("got %d points, want %d", len(points), len(want))
+	}
+
+	for i, point := range points {
+		if point.Value.Uptrend != want[i].Uptrend {
+			t.Errorf("value %d Uptrend = %v, want %v", i, point.Value.Uptrend, want[i].Uptrend)
+		}
+	}
+
+	var got, wantValues []float64
+	for i, point := range points {
+		got, wantValues = append(got, point.Value.Value), append(wantValues, want[i].Value)
+	}
+
+	assertClose(t, got, wantValues, 1e-9)
+}
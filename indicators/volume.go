@@ -0,0 +1,68 @@
+package indicators
+
+import (
+	"time"
+
+	"github.com/rctrj/growwapi-go"
+)
+
+// VWAP is the volume weighted average of the typical price, (high+low+close)/3, of the candles of the day.
+// It restarts on every IST date.
+type VWAP struct {
+	date        time.Time
+	priceVolume float64
+	volume      float64
+}
+
+// NewVWAP creates a VWAP
+func NewVWAP() *VWAP {
+	return &VWAP{}
+}
+
+// Update implements Indicator. It has a value from the first candle of the day with volume.
+func (v *VWAP) Update(candle growwapi.Candle) (float64, bool) {
+	year, month, day := candle.Timestamp.In(growwapi.IST).Date()
+	if date := time.Date(year, month, day, 0, 0, 0, 0, growwapi.IST); !date.Equal(v.date) {
+		*v = VWAP{date: date}
+	}
+
+	typicalPrice := (float64(candle.High) + float64(candle.Low) + float64(candle.Close)) / 3
+	v.priceVolume += typicalPrice * float64(candle.Volume)
+	v.volume += float64(candle.Volume)
+
+	if v.volume == 0 {
+		return 0, false
+	}
+
+	return v.priceVolume / v.volume, true
+}
+
+// OBV is the on balance volume: the running sum of volumes, added on candles closing higher than the previous one
+// and subtracted on candles closing lower. It starts at 0 on the first candle.
+type OBV struct {
+	value     float64
+	prevClose float64
+	started   bool
+}
+
+// NewOBV creates an OBV
+func NewOBV() *OBV {
+	return &OBV{}
+}
+
+// Update implements Indicator. It has a value from the first candle.
+func (o *OBV) Update(candle growwapi.Candle) (float64, bool) {
+	closePrice := float64(candle.Close)
+
+	switch {
+	case !o.started:
+		o.started = true
+	case closePrice > o.prevClose:
+		o.value += float64(candle.Volume)
+	case closePrice < o.prevClose:
+		o.value -= float64(candle.Volume)
+	}
+
+	o.prevClose = closePrice
+	return o.value, true
+}
@@ -0,0 +1,68 @@
+package indicators
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rctrj/growwapi-go"
+)
+
+func TestVWAP(t *testing.T) {
+	// high, low, close and volume of 5 minute candles over two days
+	candles := []struct {
+		timestamp                time.Time
+		high, low, close, volume float64
+	}{
+		// typical price 10
+		{timestamp: start, high: 12, low: 8, close: 10, volume: 100},
+		// typical price 13, (10*100+13*200)/300 = 12
+		{timestamp: start.Add(5 * time.Minute), high: 15, low: 12, close: 12, volume: 200},
+		// typical price 11, (3600+11*100)/400 = 11.75
+		{timestamp: start.Add(10 * time.Minute), high: 12, low: 10, close: 11, volume: 100},
+		// the next day starts without volume, hence without a value
+		{timestamp: start.AddDate(0, 0, 1), high: 21, low: 19, close: 20, volume: 0},
+		// typical price 21
+		{timestamp: start.AddDate(0, 0, 1).Add(5 * time.Minute), high: 22, low: 20, close: 21, volume: 50},
+	}
+
+	var series []growwapi.Candle
+	for _, c := range candles {
+		series = append(series, growwapi.Candle{
+			Timestamp: growwapi.Time{Time: c.timestamp},
+			Ohlcv: growwapi.Ohlcv{
+				Ohlc:   growwapi.Ohlc{Open: float32(c.close), High: float32(c.high), Low: float32(c.low), Close: float32(c.close)},
+				Volume: float32(c.volume),
+			},
+		})
+	}
+
+	points := Compute(NewVWAP(), series)
+	assertClose(t, values(points), []float64{10, 12, 11.75, 21}, 1e-9)
+
+	if got, want := points[3].Timestamp, candles[4].timestamp; !got.Equal(want) {
+		t.Errorf("Timestamp = %v, want %v", got, want)
+	}
+}
+
+func TestVWAPLargePrices(t *testing.T) {
+	// high+low isn't representable as float32, the prices are summed as float64
+	candles := []growwapi.Candle{{
+		Timestamp: growwapi.Time{Time: start},
+		Ohlcv: growwapi.Ohlcv{
+			Ohlc:   growwapi.Ohlc{Open: 2, High: 1 << 24, Low: 1, Close: 2},
+			Volume: 1,
+		},
+	}}
+
+	assertClose(t, values(Compute(NewVWAP(), candles)), []float64{(1<<24 + 3) / 3.0}, 1e-9)
+}
+
+func TestOBV(t *testing.T) {
+	candles := closeCandles(10, 11, 11, 9, 12)
+	for i, volume := range []float32{100, 200, 300, 400, 500} {
+		candles[i].Volume = volume
+	}
+
+	// starts at 0, adds 200 on the higher close, keeps it on the unchanged one, subtracts 400 and adds 500
+	assertClose(t, values(Compute(NewOBV(), candles)), []float64{0, 200, 200, -200, 300}, 0)
+}
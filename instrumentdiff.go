@@ -0,0 +1,233 @@
+package growwapi
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+)
+
+// InstrumentChangeKind represents the kind of change between two snapshots of the instrument master
+type InstrumentChangeKind string
+
+const (
+	// InstrumentChangeAdded - Instrument is only present in the new snapshot
+	InstrumentChangeAdded InstrumentChangeKind = "ADDED"
+
+	// InstrumentChangeRemoved - Instrument is only present in the old snapshot
+	InstrumentChangeRemoved InstrumentChangeKind = "REMOVED"
+
+	// InstrumentChangeRenamed - TradingSymbol of the instrument changed
+	InstrumentChangeRenamed InstrumentChangeKind = "RENAMED"
+
+	// InstrumentChangeLotSize - LotSize of the instrument changed
+	InstrumentChangeLotSize InstrumentChangeKind = "LOT_SIZE"
+
+	// InstrumentChangeTickSize - TickSize of the instrument changed
+	InstrumentChangeTickSize InstrumentChangeKind = "TICK_SIZE"
+
+	// InstrumentChangeFreezeQuantity - FreezeQuantity of the instrument changed
+	InstrumentChangeFreezeQuantity InstrumentChangeKind = "FREEZE_QUANTITY"
+
+	// InstrumentChangeBuyAllowed - BuyAllowed of the instrument flipped
+	InstrumentChangeBuyAllowed InstrumentChangeKind = "BUY_ALLOWED"
+
+	// InstrumentChangeSellAllowed - SellAllowed of the instrument flipped
+	InstrumentChangeSellAllowed InstrumentChangeKind = "SELL_ALLOWED"
+
+	// InstrumentChangeIsReserved - IsReserved of the instrument flipped
+	InstrumentChangeIsReserved InstrumentChangeKind = "IS_RESERVED"
+)
+
+// InstrumentChange represents a change of an instrument between two snapshots of the instrument master
+type InstrumentChange struct {
+	// Kind of the change
+	Kind InstrumentChangeKind
+	// Instrument in the old snapshot. Zero value for InstrumentChangeAdded
+	Old Instrument
+	// Instrument in the new snapshot. Zero value for InstrumentChangeRemoved
+	New Instrument
+}
+
+// Instrument returns the instrument as of the newest snapshot it is present in
+func (c InstrumentChange) Instrument() Instrument {
+	if c.Kind == InstrumentChangeRemoved {
+		return c.Old
+	}
+
+	return c.New
+}
+
+// Values returns the old and new value of the changed field, formatted for display.
+// Empty for InstrumentChangeAdded and InstrumentChangeRemoved.
+func (c InstrumentChange) Values() (before, after string) {
+	switch c.Kind {
+	case InstrumentChangeRenamed:
+		return c.Old.TradingSymbol, c.New.TradingSymbol
+	case InstrumentChangeLotSize:
+		return fmt.Sprint(c.Old.LotSize), fmt.Sprint(c.New.LotSize)
+	case InstrumentChangeTickSize:
+		return fmt.Sprint(c.Old.TickSize), fmt.Sprint(c.New.TickSize)
+	case InstrumentChangeFreezeQuantity:
+		return fmt.Sprint(c.Old.FreezeQuantity), fmt.Sprint(c.New.FreezeQuantity)
+	case InstrumentChangeBuyAllowed:
+		return fmt.Sprint(c.Old.BuyAllowed), fmt.Sprint(c.New.BuyAllowed)
+	case InstrumentChangeSellAllowed:
+		return fmt.Sprint(c.Old.SellAllowed), fmt.Sprint(c.New.SellAllowed)
+	case InstrumentChangeIsReserved:
+		return fmt.Sprint(c.Old.IsReserved), fmt.Sprint(c.New.IsReserved)
+	default:
+		return "", ""
+	}
+}
+
+func (c InstrumentChange) String() string {
+	instrument := c.Instrument()
+	prefix := fmt.Sprintf("%s %s %s:%s", c.Kind, instrument.Segment, instrument.Exchange, instrument.TradingSymbol)
+
+	if before, after := c.Values(); before != "" || after != "" {
+		return fmt.Sprintf("%s %s -> %s", prefix, before, after)
+	}
+
+	return prefix
+}
+
+// DiffInstruments compares two snapshots of the instrument master and returns the changes between them,
+// ordered by kind, exchange, segment and trading symbol.
+//
+// Instruments are matched on exchange token within the exchange and segment, as long as the matched instruments have
+// the same type, ISIN, underlying, expiry and strike. Exchanges reuse the tokens of expired contracts, so a token
+// which now identifies a different contract is reported as REMOVED and ADDED rather than RENAMED.
+// Instruments left unmatched are matched on ISIN within the exchange, which catches symbol changes where the exchange
+// token was reissued as well.
+func DiffInstruments(before, after []Instrument) []InstrumentChange {
+	type pair struct{ old, new *Instrument }
+
+	type tokenKey struct {
+		exchange Exchange
+		segment  Segment
+		token    string
+	}
+
+	oldByToken := make(map[tokenKey]*Instrument, len(before))
+	for i := range before {
+		oldByToken[tokenKey{before[i].Exchange, before[i].Segment, before[i].ExchangeToken}] = &before[i]
+	}
+
+	var pairs []pair
+	var added []*Instrument
+
+	for i := range after {
+		key := tokenKey{after[i].Exchange, after[i].Segment, after[i].ExchangeToken}
+		if match, ok := oldByToken[key]; ok && sameContract(*match, after[i]) {
+			pairs = append(pairs, pair{match, &after[i]})
+			delete(oldByToken, key)
+			continue
+		}
+
+		added = append(added, &after[i])
+	}
+
+	// match the leftovers on ISIN, as long as the ISIN identifies a single instrument on both sides
+	type isinKey struct {
+		exchange Exchange
+		isin     string
+	}
+
+	removedByIsin := make(map[isinKey][]*Instrument)
+	var removed []*Instrument
+	for _, instrument := range oldByToken {
+		if instrument.Isin == "" {
+			removed = append(removed, instrument)
+			continue
+		}
+
+		key := isinKey{instrument.Exchange, instrument.Isin}
+		removedByIsin[key] = append(removedByIsin[key], instrument)
+	}
+
+	addedByIsin := make(map[isinKey]int)
+	for _, instrument := range added {
+		if instrument.Isin != "" {
+			addedByIsin[isinKey{instrument.Exchange, instrument.Isin}]++
+		}
+	}
+
+	var stillAdded []*Instrument
+	for _, instrument := range added {
+		key := isinKey{instrument.Exchange, instrument.Isin}
+		if instrument.Isin != "" && addedByIsin[key] == 1 && len(removedByIsin[key]) == 1 {
+			pairs = append(pairs, pair{removedByIsin[key][0], instrument})
+			delete(removedByIsin, key)
+			continue
+		}
+
+		stillAdded = append(stillAdded, instrument)
+	}
+
+	for _, instruments := range removedByIsin {
+		removed = append(removed, instruments...)
+	}
+
+	var out []InstrumentChange
+	for _, instrument := range stillAdded {
+		out = append(out, InstrumentChange{Kind: InstrumentChangeAdded, New: *instrument})
+	}
+
+	for _, instrument := range removed {
+		out = append(out, InstrumentChange{Kind: InstrumentChangeRemoved, Old: *instrument})
+	}
+
+	for _, p := range pairs {
+		out = append(out, diffInstrument(*p.old, *p.new)...)
+	}
+
+	slices.SortFunc(out, func(a, b InstrumentChange) int {
+		x, y := a.Instrument(), b.Instrument()
+		return cmp.Or(
+			cmp.Compare(a.Kind, b.Kind),
+			cmp.Compare(x.Exchange, y.Exchange),
+			cmp.Compare(x.Segment, y.Segment),
+			cmp.Compare(x.TradingSymbol, y.TradingSymbol),
+			cmp.Compare(x.ExchangeToken, y.ExchangeToken),
+		)
+	})
+
+	return out
+}
+
+// sameContract reports whether a and b identify the same contract, regardless of their trading symbols
+func sameContract(a, b Instrument) bool {
+	if a.InstrumentType != b.InstrumentType || a.UnderlyingSymbol != b.UnderlyingSymbol || a.StrikePrice != b.StrikePrice {
+		return false
+	}
+
+	if a.Isin != "" && b.Isin != "" && a.Isin != b.Isin {
+		return false
+	}
+
+	x, y := a.ExpiryDate.Time, b.ExpiryDate.Time
+	if x == nil || y == nil {
+		return x == y
+	}
+
+	return x.Equal(*y)
+}
+
+func diffInstrument(before, after Instrument) []InstrumentChange {
+	var out []InstrumentChange
+	change := func(kind InstrumentChangeKind, changed bool) {
+		if changed {
+			out = append(out, InstrumentChange{Kind: kind, Old: before, New: after})
+		}
+	}
+
+	change(InstrumentChangeRenamed, before.TradingSymbol != after.TradingSymbol)
+	change(InstrumentChangeLotSize, before.LotSize != after.LotSize)
+	change(InstrumentChangeTickSize, before.TickSize != after.TickSize)
+	change(InstrumentChangeFreezeQuantity, before.FreezeQuantity != after.FreezeQuantity)
+	change(InstrumentChangeBuyAllowed, before.BuyAllowed != after.BuyAllowed)
+	change(InstrumentChangeSellAllowed, before.SellAllowed != after.SellAllowed)
+	change(InstrumentChangeIsReserved, before.IsReserved != after.IsReserved)
+
+	return out
+}
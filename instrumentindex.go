@@ -0,0 +1,299 @@
+package growwapi
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"time"
+)
+
+type instrumentTokenKey struct {
+	exchange Exchange
+	segment  Segment
+	token    string
+}
+
+type instrumentSymbolKey struct {
+	exchange Exchange
+	symbol   string
+}
+
+// InstrumentIndex indexes instruments for constant time lookups and derivative queries.
+// InstrumentIndex is immutable once created and safe for concurrent use.
+type InstrumentIndex struct {
+	instruments []Instrument
+
+	byToken       map[instrumentTokenKey]int
+	bySymbol      map[instrumentSymbolKey]int
+	byGrowwSymbol map[string]int
+	byIsin        map[string][]int
+	// derivatives of an underlying, ordered by expiry, strike price and instrument type
+	derivatives map[instrumentSymbolKey][]int
+
+	// lower cased trading symbols and names for Search
+	searchSymbols []string
+	searchNames   []string
+}
+
+// NewInstrumentIndex creates a new InstrumentIndex over instruments.
+// instruments is retained by the index and must not be modified afterwards.
+func NewInstrumentIndex(instruments []Instrument) *InstrumentIndex {
+	idx := &InstrumentIndex{
+		instruments:   instruments,
+		byToken:       make(map[instrumentTokenKey]int, len(instruments)),
+		bySymbol:      make(map[instrumentSymbolKey]int, len(instruments)),
+		byGrowwSymbol: make(map[string]int, len(instruments)),
+		byIsin:        make(map[string][]int),
+		derivatives:   make(map[instrumentSymbolKey][]int),
+		searchSymbols: make([]string, len(instruments)),
+		searchNames:   make([]string, len(instruments)),
+	}
+
+	for i, instrument := range instruments {
+		idx.byToken[instrumentTokenKey{instrument.Exchange, instrument.Segment, instrument.ExchangeToken}] = i
+		idx.bySymbol[instrumentSymbolKey{instrument.Exchange, instrument.TradingSymbol}] = i
+
+		if instrument.GrowwSymbol != "" {
+			idx.byGrowwSymbol[instrument.GrowwSymbol] = i
+		}
+
+		if instrument.Isin != "" {
+			idx.byIsin[instrument.Isin] = append(idx.byIsin[instrument.Isin], i)
+		}
+
+		if instrument.UnderlyingSymbol != "" && instrument.ExpiryDate.Time != nil {
+			key := instrumentSymbolKey{instrument.Exchange, instrument.UnderlyingSymbol}
+			idx.derivatives[key] = append(idx.derivatives[key], i)
+		}
+
+		idx.searchSymbols[i] = strings.ToLower(instrument.TradingSymbol)
+		idx.searchNames[i] = strings.ToLower(instrument.Name)
+	}
+
+	for _, derivatives := range idx.derivatives {
+		slices.SortFunc(derivatives, func(a, b int) int {
+			x, y := &instruments[a], &instruments[b]
+			return cmp.Or(
+				x.ExpiryDate.Compare(*y.ExpiryDate.Time),
+				cmp.Compare(x.StrikePrice, y.StrikePrice),
+				cmp.Compare(x.InstrumentType, y.InstrumentType),
+			)
+		})
+	}
+
+	return idx
+}
+
+// Instruments returns all the indexed instruments. The returned slice is shared and must not be modified.
+func (idx *InstrumentIndex) Instruments() []Instrument {
+	return idx.instruments
+}
+
+// ByExchangeToken returns the instrument with the token on the exchange and segment.
+// Exchange tokens are only unique within a segment of an exchange.
+func (idx *InstrumentIndex) ByExchangeToken(exchange Exchange, segment Segment, exchangeToken string) (Instrument, bool) {
+	return lookupInstrument(idx, idx.byToken, instrumentTokenKey{exchange, segment, exchangeToken})
+}
+
+// ByTradingSymbol returns the instrument with the trading symbol on the exchange
+func (idx *InstrumentIndex) ByTradingSymbol(exchange Exchange, tradingSymbol string) (Instrument, bool) {
+	return lookupInstrument(idx, idx.bySymbol, instrumentSymbolKey{exchange, tradingSymbol})
+}
+
+// ByGrowwSymbol returns the instrument with the groww symbol
+func (idx *InstrumentIndex) ByGrowwSymbol(growwSymbol string) (Instrument, bool) {
+	return lookupInstrument(idx, idx.byGrowwSymbol, growwSymbol)
+}
+
+// ByIsin returns the instruments with the ISIN, one per exchange the instrument is listed on
+func (idx *InstrumentIndex) ByIsin(isin string) []Instrument {
+	return idx.collect(idx.byIsin[isin])
+}
+
+func lookupInstrument[K comparable](idx *InstrumentIndex, m map[K]int, key K) (Instrument, bool) {
+	i, ok := m[key]
+	if !ok {
+		return Instrument{}, false
+	}
+
+	return idx.instruments[i], true
+}
+
+func (idx *InstrumentIndex) collect(indices []int) []Instrument {
+	if len(indices) == 0 {
+		return nil
+	}
+
+	out := make([]Instrument, len(indices))
+	for i, j := range indices {
+		out[i] = idx.instruments[j]
+	}
+
+	return out
+}
+
+// Expiries returns the distinct expiry dates of the derivatives of the underlying on the exchange, in ascending order
+func (idx *InstrumentIndex) Expiries(exchange Exchange, underlying string) []time.Time {
+	var out []time.Time
+	for _, i := range idx.derivatives[instrumentSymbolKey{exchange, underlying}] {
+		expiry := *idx.instruments[i].ExpiryDate.Time
+		if len(out) == 0 || !out[len(out)-1].Equal(expiry) {
+			out = append(out, expiry)
+		}
+	}
+
+	return out
+}
+
+// Options returns the call and put options of the underlying on the exchange expiring on the date of expiry,
+// ordered by strike price
+func (idx *InstrumentIndex) Options(exchange Exchange, underlying string, expiry time.Time) []Instrument {
+	date := expiry.In(IST).Format(time.DateOnly)
+
+	var out []Instrument
+	for _, i := range idx.derivatives[instrumentSymbolKey{exchange, underlying}] {
+		instrument := idx.instruments[i]
+		if !isOptionInstrument(instrument.InstrumentType) || instrument.ExpiryDate.In(IST).Format(time.DateOnly) != date {
+			continue
+		}
+
+		out = append(out, instrument)
+	}
+
+	return out
+}
+
+// NearestFuture returns the future of the underlying on the exchange with the nearest expiry on or after the date of from
+func (idx *InstrumentIndex) NearestFuture(exchange Exchange, underlying string, from time.Time) (Instrument, bool) {
+	date := from.In(IST).Format(time.DateOnly)
+
+	for _, i := range idx.derivatives[instrumentSymbolKey{exchange, underlying}] {
+		instrument := idx.instruments[i]
+		if instrument.InstrumentType == InstrumentTypeFutures && instrument.ExpiryDate.In(IST).Format(time.DateOnly) >= date {
+			return instrument, true
+		}
+	}
+
+	return Instrument{}, false
+}
+
+// StrikesAround returns the options of the underlying on the exchange expiring on the date of expiry, with n strikes
+// at or below spot and n strikes above it. Both call and put options are returned for every strike, ordered by strike price.
+func (idx *InstrumentIndex) StrikesAround(exchange Exchange, underlying string, expiry time.Time, spot float32, n int) []Instrument {
+	options := idx.Options(exchange, underlying, expiry)
+
+	var strikes []int
+	for _, option := range options {
+		if len(strikes) == 0 || strikes[len(strikes)-1] != option.StrikePrice {
+			strikes = append(strikes, option.StrikePrice)
+		}
+	}
+
+	// first strike above spot
+	above, _ := slices.BinarySearchFunc(strikes, spot, func(strike int, spot float32) int {
+		if float32(strike) <= spot {
+			return -1
+		}
+		return 1
+	})
+
+	low, high := max(above-n, 0), min(above+n, len(strikes))
+	if low >= high {
+		return nil
+	}
+
+	minStrike, maxStrike := strikes[low], strikes[high-1]
+	var out []Instrument
+	for _, option := range options {
+		if option.StrikePrice >= minStrike && option.StrikePrice <= maxStrike {
+			out = append(out, option)
+		}
+	}
+
+	return out
+}
+
+func isOptionInstrument(instrumentType InstrumentType) bool {
+	return instrumentType == InstrumentTypeCallOption || instrumentType == InstrumentTypePutOption
+}
+
+// Search returns up to limit instruments whose trading symbol or name matches query, best matches first.
+// Exact symbol matches rank first, followed by symbol prefixes, name word prefixes, substrings and finally
+// fuzzy matches where the characters of query appear in order. Equities and indices rank above derivatives
+// within the same kind of match.
+func (idx *InstrumentIndex) Search(query string, limit int) []Instrument {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" || limit <= 0 {
+		return nil
+	}
+
+	type match struct {
+		i     int
+		score int
+	}
+
+	var matches []match
+	for i := range idx.instruments {
+		if score := searchScore(query, idx.searchSymbols[i], idx.searchNames[i]); score > 0 {
+			matches = append(matches, match{i, score})
+		}
+	}
+
+	slices.SortFunc(matches, func(a, b match) int {
+		x, y := &idx.instruments[a.i], &idx.instruments[b.i]
+		return cmp.Or(
+			cmp.Compare(b.score, a.score),
+			cmp.Compare(searchTypeRank(x.InstrumentType), searchTypeRank(y.InstrumentType)),
+			cmp.Compare(len(x.TradingSymbol), len(y.TradingSymbol)),
+			cmp.Compare(x.TradingSymbol, y.TradingSymbol),
+			cmp.Compare(x.Exchange, y.Exchange),
+		)
+	})
+
+	out := make([]Instrument, 0, min(limit, len(matches)))
+	for _, m := range matches[:min(limit, len(matches))] {
+		out = append(out, idx.instruments[m.i])
+	}
+
+	return out
+}
+
+func searchScore(query, symbol, name string) int {
+	switch {
+	case symbol == query:
+		return 6
+	case strings.HasPrefix(symbol, query):
+		return 5
+	case name == query || strings.HasPrefix(name, query):
+		return 4
+	case strings.Contains(" "+name, " "+query):
+		return 3
+	case strings.Contains(symbol, query) || strings.Contains(name, query):
+		return 2
+	case isSubsequence(query, symbol) || isSubsequence(query, name):
+		return 1
+	default:
+		return 0
+	}
+}
+
+func isSubsequence(query, s string) bool {
+	for i := 0; i < len(s) && len(query) > 0; i++ {
+		if s[i] == query[0] {
+			query = query[1:]
+		}
+	}
+
+	return len(query) == 0
+}
+
+func searchTypeRank(instrumentType InstrumentType) int {
+	switch instrumentType {
+	case InstrumentTypeEquity, InstrumentTypeIndex:
+		return 0
+	case InstrumentTypeFutures:
+		return 1
+	default:
+		return 2
+	}
+}
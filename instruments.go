@@ -5,6 +5,7 @@ package growwapi
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/http"
 
 	"github.com/gocarina/gocsv"
@@ -74,8 +75,6 @@ func Instruments(ctx context.Context, httpClient *http.Client) ([]Instrument, er
 		httpClient = http.DefaultClient
 	}
 
-	const instrumentsUrl = "https://growwapi-assets.groww.in/instruments/instrument.csv"
-
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, instrumentsUrl, nil)
 	if err != nil {
 		return nil, fmt.Errorf("NewRequestWithContext: %w", err)
@@ -87,8 +86,16 @@ func Instruments(ctx context.Context, httpClient *http.Client) ([]Instrument, er
 	}
 	defer resp.Body.Close()
 
+	return ParseInstruments(resp.Body)
+}
+
+// instrumentsUrl is where the instrument master csv is published
+const instrumentsUrl = "https://growwapi-assets.groww.in/instruments/instrument.csv"
+
+// ParseInstruments parses an instrument csv, such as the one downloaded by Instruments or cached by InstrumentStore
+func ParseInstruments(r io.Reader) ([]Instrument, error) {
 	var out []Instrument
-	if err := gocsv.Unmarshal(resp.Body, &out); err != nil {
+	if err := gocsv.Unmarshal(r, &out); err != nil {
 		return nil, fmt.Errorf("gocsv.Unmarshal(): %w", err)
 	}
 
@@ -0,0 +1,307 @@
+package growwapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	instrumentStoreCsvFile  = "instrument.csv"
+	instrumentStoreMetaFile = "instrument.meta.json"
+
+	// instrumentStoreCheckInterval is how often InstrumentStore.Run checks whether the instruments are stale
+	instrumentStoreCheckInterval = 15 * time.Minute
+)
+
+// instrumentPublishTime is the time of day by which the instrument master of a trading day is published
+var instrumentPublishTime = clock{8, 0}
+
+// instrumentCacheMeta is persisted next to the cached csv
+type instrumentCacheMeta struct {
+	// Time the csv was last fetched or validated at
+	FetchedAt time.Time `json:"fetched_at"`
+	// ETag header of the last fetched csv
+	ETag string `json:"etag,omitempty"`
+	// Last-Modified header of the last fetched csv
+	LastModified string `json:"last_modified,omitempty"`
+}
+
+type instrumentSnapshot struct {
+	index *InstrumentIndex
+	meta  instrumentCacheMeta
+}
+
+// InstrumentStore caches the instrument master on disk and serves it from memory.
+// The instrument master is published before the market opens on every trading day. The cached csv is refreshed when
+// it was fetched before the publish time of the latest trading day, as per the MarketCalendar of the store, using
+// ETag and Last-Modified to avoid downloading an unchanged file.
+//
+// Lookups are served from the last loaded snapshot while a refresh is in progress, and the snapshot is swapped
+// atomically once the refresh completes.
+type InstrumentStore struct {
+	httpClient *http.Client
+	dir        string
+	calendar   *MarketCalendar
+
+	current   atomic.Pointer[instrumentSnapshot]
+	ready     chan struct{}
+	readyOnce sync.Once
+
+	// refreshMu serialises loads and refreshes
+	refreshMu sync.Mutex
+}
+
+// NewInstrumentStore creates a new InstrumentStore caching the instrument master in dir.
+// calendar decides the trading days, DefaultMarketCalendar is used if it is nil.
+// The store is empty until it is loaded with InstrumentStore.Load or InstrumentStore.Run.
+func NewInstrumentStore(httpClient *http.Client, dir string, calendar *MarketCalendar) *InstrumentStore {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	if calendar == nil {
+		calendar = DefaultMarketCalendar()
+	}
+
+	return &InstrumentStore{
+		httpClient: httpClient,
+		dir:        dir,
+		calendar:   calendar,
+		ready:      make(chan struct{}),
+	}
+}
+
+// Run loads the store and refreshes it whenever it goes stale, until ctx is done.
+// Failed refreshes are retried on the next check while the last loaded instruments keep being served.
+func (s *InstrumentStore) Run(ctx context.Context) error {
+	ticker := time.NewTicker(instrumentStoreCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		// a failed load is retried on the next tick
+		_ = s.Load(ctx)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Load loads the cached csv from disk if nothing is loaded yet, and refreshes it if it was fetched before the latest
+// instrument master was published.
+func (s *InstrumentStore) Load(ctx context.Context) error {
+	s.refreshMu.Lock()
+	defer s.refreshMu.Unlock()
+
+	if s.current.Load() == nil {
+		if err := s.loadFromDisk(); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+
+	if snapshot := s.current.Load(); snapshot != nil && !snapshot.meta.FetchedAt.Before(s.publishedAt(time.Now())) {
+		return nil
+	}
+
+	return s.refresh(ctx)
+}
+
+// Refresh fetches the instrument master regardless of when it was last fetched.
+// The request is conditional on the cached csv having changed.
+func (s *InstrumentStore) Refresh(ctx context.Context) error {
+	s.refreshMu.Lock()
+	defer s.refreshMu.Unlock()
+
+	return s.refresh(ctx)
+}
+
+// Ready returns a channel which is closed once instruments are loaded for the first time
+func (s *InstrumentStore) Ready() <-chan struct{} {
+	return s.ready
+}
+
+// Wait blocks until instruments are loaded for the first time or ctx is done
+func (s *InstrumentStore) Wait(ctx context.Context) error {
+	select {
+	case <-s.ready:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Instruments returns the loaded instruments, or nil if the store is not loaded yet.
+// The returned slice is shared and must not be modified.
+func (s *InstrumentStore) Instruments() []Instrument {
+	if snapshot := s.current.Load(); snapshot != nil {
+		return snapshot.index.Instruments()
+	}
+
+	return nil
+}
+
+// Index returns the InstrumentIndex over the loaded instruments, or nil if the store is not loaded yet.
+// A new index is built whenever the instruments change, so the returned index is a consistent snapshot.
+func (s *InstrumentStore) Index() *InstrumentIndex {
+	if snapshot := s.current.Load(); snapshot != nil {
+		return snapshot.index
+	}
+
+	return nil
+}
+
+// FetchedAt returns the time the loaded instruments were last fetched or validated at.
+// Zero if the store is not loaded yet or the cached csv has no fetch time.
+func (s *InstrumentStore) FetchedAt() time.Time {
+	if snapshot := s.current.Load(); snapshot != nil {
+		return snapshot.meta.FetchedAt
+	}
+
+	return time.Time{}
+}
+
+// publishedAt returns the time the latest instrument master as of t was published at,
+// which is the publish time of the last trading day reached by t
+func (s *InstrumentStore) publishedAt(t time.Time) time.Time {
+	d := startOfDate(t)
+	if s.calendar.IsTradingDay(ExchangeNse, SegmentCash, d) {
+		if published := instrumentPublishTime.on(d.Date()); !t.Before(published) {
+			return published
+		}
+	}
+
+	previous, ok := s.calendar.PreviousTradingDay(ExchangeNse, SegmentCash, d)
+	if !ok {
+		// no trading day for a year, refetching from the previous date on is the safe choice
+		previous = d.AddDate(0, 0, -1)
+	}
+
+	return instrumentPublishTime.on(previous.Date())
+}
+
+func (s *InstrumentStore) loadFromDisk() error {
+	f, err := os.Open(filepath.Join(s.dir, instrumentStoreCsvFile))
+	if err != nil {
+		return fmt.Errorf("os.Open: %w", err)
+	}
+	defer f.Close()
+
+	instruments, err := ParseInstruments(f)
+	if err != nil {
+		return err
+	}
+
+	// a csv without meta is served, but considered stale
+	var meta instrumentCacheMeta
+	if data, err := os.ReadFile(filepath.Join(s.dir, instrumentStoreMetaFile)); err == nil {
+		if err := json.Unmarshal(data, &meta); err != nil {
+			meta = instrumentCacheMeta{}
+		}
+	}
+
+	s.swap(&instrumentSnapshot{index: NewInstrumentIndex(instruments), meta: meta})
+	return nil
+}
+
+func (s *InstrumentStore) refresh(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, instrumentsUrl, nil)
+	if err != nil {
+		return fmt.Errorf("NewRequestWithContext: %w", err)
+	}
+
+	previous := s.current.Load()
+	if previous != nil {
+		if previous.meta.ETag != "" {
+			req.Header.Set("If-None-Match", previous.meta.ETag)
+		}
+
+		if previous.meta.LastModified != "" {
+			req.Header.Set("If-Modified-Since", previous.meta.LastModified)
+		}
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("httpClient.Get(%q): %w", instrumentsUrl, err)
+	}
+	defer resp.Body.Close()
+
+	meta := instrumentCacheMeta{
+		FetchedAt:    time.Now().In(IST),
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && previous != nil:
+		if meta.ETag == "" {
+			meta.ETag = previous.meta.ETag
+		}
+
+		if meta.LastModified == "" {
+			meta.LastModified = previous.meta.LastModified
+		}
+
+		if err := s.writeMeta(meta); err != nil {
+			return err
+		}
+
+		s.swap(&instrumentSnapshot{index: previous.index, meta: meta})
+		return nil
+
+	case resp.StatusCode == http.StatusOK:
+		data, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return fmt.Errorf("io.ReadAll: %w", err)
+		}
+
+		instruments, err := ParseInstruments(bytes.NewReader(data))
+		if err != nil {
+			return err
+		}
+
+		if err := os.MkdirAll(s.dir, 0o755); err != nil {
+			return fmt.Errorf("os.MkdirAll: %w", err)
+		}
+
+		if err := writeFileAtomic(filepath.Join(s.dir, instrumentStoreCsvFile), data); err != nil {
+			return err
+		}
+
+		if err := s.writeMeta(meta); err != nil {
+			return err
+		}
+
+		s.swap(&instrumentSnapshot{index: NewInstrumentIndex(instruments), meta: meta})
+		return nil
+
+	default:
+		return fmt.Errorf("httpClient.Get(%q): unexpected status %s", instrumentsUrl, resp.Status)
+	}
+}
+
+func (s *InstrumentStore) writeMeta(meta instrumentCacheMeta) error {
+	data, err := json.Marshal(meta)
+	if err != nil {
+		return fmt.Errorf("json.Marshal: %w", err)
+	}
+
+	return writeFileAtomic(filepath.Join(s.dir, instrumentStoreMetaFile), data)
+}
+
+func (s *InstrumentStore) swap(snapshot *instrumentSnapshot) {
+	s.current.Store(snapshot)
+	s.readyOnce.Do(func() { close(s.ready) })
+}
@@ -0,0 +1,291 @@
+package growwapi
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// InstrumentFilter selects the instruments yielded by Client.StreamInstruments.
+// Empty fields match everything, and an instrument has to match all the non-empty fields.
+type InstrumentFilter struct {
+	// [Optional] Exchanges to include
+	Exchanges []Exchange
+	// [Optional] Segments to include
+	Segments []Segment
+	// [Optional] Instrument types to include
+	InstrumentTypes []InstrumentType
+	// [Optional] Underlying symbols to include. Only derivatives have an underlying symbol
+	UnderlyingSymbols []string
+	// [Optional] Predicate applied to the decoded instrument after the other fields matched
+	Match func(Instrument) bool
+}
+
+// InstrumentRowError is yielded by Client.StreamInstruments for a row which can't be decoded.
+// Streaming continues with the next row.
+type InstrumentRowError struct {
+	// Line of the row in the csv, starting at 1 for the header
+	Line int
+	// Err is the decoding error
+	Err error
+}
+
+func (e InstrumentRowError) Error() string {
+	return fmt.Sprintf("instrument csv line %d: %v", e.Line, e.Err)
+}
+
+func (e InstrumentRowError) Unwrap() error {
+	return e.Err
+}
+
+// StreamInstruments returns an iterator over the instruments matching filter, decoding the instrument csv row by row
+// as it is downloaded. Rows are matched against the filter before being fully decoded, so only the matching
+// instruments are ever held in memory.
+//
+// Malformed rows yield an InstrumentRowError and the iteration continues. Any other error ends the iteration.
+func (c *Client) StreamInstruments(ctx context.Context, filter InstrumentFilter) iter.Seq2[Instrument, error] {
+	return StreamInstruments(ctx, c.httpClient, filter)
+}
+
+// StreamInstruments returns an iterator over the instruments matching filter, decoding the instrument csv row by row
+// as it is downloaded. Rows are matched against the filter before being fully decoded, so only the matching
+// instruments are ever held in memory.
+//
+// Malformed rows yield an InstrumentRowError and the iteration continues. Any other error ends the iteration.
+func StreamInstruments(ctx context.Context, httpClient *http.Client, filter InstrumentFilter) iter.Seq2[Instrument, error] {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	return func(yield func(Instrument, error) bool) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, instrumentsUrl, nil)
+		if err != nil {
+			yield(Instrument{}, fmt.Errorf("NewRequestWithContext: %w", err))
+			return
+		}
+
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			yield(Instrument{}, fmt.Errorf("httpClient.Get(%q): %w", instrumentsUrl, err))
+			return
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			yield(Instrument{}, fmt.Errorf("httpClient.Get(%q): unexpected status %s", instrumentsUrl, resp.Status))
+			return
+		}
+
+		for instrument, err := range streamInstruments(resp.Body, filter) {
+			if !yield(instrument, err) {
+				return
+			}
+		}
+	}
+}
+
+// instrumentColumns holds the position of every csv column in the header, -1 if the column is absent
+type instrumentColumns struct {
+	exchange, exchangeToken, tradingSymbol, growwSymbol, name, instrumentType, segment, series, isin,
+	underlyingSymbol, underlyingExchangeToken, lotSize, expiryDate, strikePrice, tickSize, freezeQuantity,
+	isReserved, buyAllowed, sellAllowed int
+}
+
+func newInstrumentColumns(header []string) instrumentColumns {
+	position := func(name string) int {
+		return slices.IndexFunc(header, func(column string) bool { return strings.TrimSpace(column) == name })
+	}
+
+	return instrumentColumns{
+		exchange:                position("exchange"),
+		exchangeToken:           position("exchange_token"),
+		tradingSymbol:           position("trading_symbol"),
+		growwSymbol:             position("groww_symbol"),
+		name:                    position("name"),
+		instrumentType:          position("instrument_type"),
+		segment:                 position("segment"),
+		series:                  position("series"),
+		isin:                    position("isin"),
+		underlyingSymbol:        position("underlying_symbol"),
+		underlyingExchangeToken: position("underlying_exchange_token"),
+		lotSize:                 position("lot_size"),
+		expiryDate:              position("expiry_date"),
+		strikePrice:             position("strike_price"),
+		tickSize:                position("tick_size"),
+		freezeQuantity:          position("freeze_quantity"),
+		isReserved:              position("is_reserved"),
+		buyAllowed:              position("buy_allowed"),
+		sellAllowed:             position("sell_allowed"),
+	}
+}
+
+func streamInstruments(r io.Reader, filter InstrumentFilter) iter.Seq2[Instrument, error] {
+	return func(yield func(Instrument, error) bool) {
+		reader := csv.NewReader(r)
+		reader.ReuseRecord = true
+		reader.FieldsPerRecord = -1
+
+		header, err := reader.Read()
+		if err != nil {
+			yield(Instrument{}, fmt.Errorf("csv header: %w", err))
+			return
+		}
+
+		columns := newInstrumentColumns(header)
+
+		for {
+			record, err := reader.Read()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				if !yield(Instrument{}, InstrumentRowError{Line: parseErr.StartLine, Err: err}) {
+					return
+				}
+				continue
+			}
+
+			if err != nil {
+				yield(Instrument{}, fmt.Errorf("csv.Read: %w", err))
+				return
+			}
+
+			// FieldPos is only valid after a successful Read
+			line, _ := reader.FieldPos(0)
+
+			if !filter.matchesRow(columns, record) {
+				continue
+			}
+
+			instrument, err := decodeInstrumentRow(columns, record)
+			if err != nil {
+				if !yield(Instrument{}, InstrumentRowError{Line: line, Err: err}) {
+					return
+				}
+				continue
+			}
+
+			if filter.Match != nil && !filter.Match(instrument) {
+				continue
+			}
+
+			if !yield(instrument, nil) {
+				return
+			}
+		}
+	}
+}
+
+// matchesRow matches the raw columns of a row, before the row is decoded
+func (f InstrumentFilter) matchesRow(columns instrumentColumns, record []string) bool {
+	return matchesColumn(f.Exchanges, column(record, columns.exchange)) &&
+		matchesColumn(f.Segments, column(record, columns.segment)) &&
+		matchesColumn(f.InstrumentTypes, column(record, columns.instrumentType)) &&
+		matchesColumn(f.UnderlyingSymbols, column(record, columns.underlyingSymbol))
+}
+
+func matchesColumn[T ~string](allowed []T, value string) bool {
+	return len(allowed) == 0 || slices.Contains(allowed, T(value))
+}
+
+func column(record []string, i int) string {
+	if i < 0 || i >= len(record) {
+		return ""
+	}
+
+	return strings.TrimSpace(record[i])
+}
+
+// decodeInstrumentRow decodes a row the same way gocsv decodes it in Instruments
+func decodeInstrumentRow(columns instrumentColumns, record []string) (Instrument, error) {
+	out := Instrument{
+		Exchange:                Exchange(column(record, columns.exchange)),
+		ExchangeToken:           column(record, columns.exchangeToken),
+		TradingSymbol:           column(record, columns.tradingSymbol),
+		GrowwSymbol:             column(record, columns.growwSymbol),
+		Name:                    column(record, columns.name),
+		InstrumentType:          InstrumentType(column(record, columns.instrumentType)),
+		Segment:                 Segment(column(record, columns.segment)),
+		Series:                  column(record, columns.series),
+		Isin:                    column(record, columns.isin),
+		UnderlyingSymbol:        column(record, columns.underlyingSymbol),
+		UnderlyingExchangeToken: column(record, columns.underlyingExchangeToken),
+	}
+
+	var err error
+
+	if out.LotSize, err = parseCsvInt(column(record, columns.lotSize)); err != nil {
+		return Instrument{}, fmt.Errorf("lot_size: %w", err)
+	}
+
+	if err := out.ExpiryDate.UnmarshalCSV(column(record, columns.expiryDate)); err != nil {
+		return Instrument{}, fmt.Errorf("expiry_date: %w", err)
+	}
+
+	if out.StrikePrice, err = parseCsvInt(column(record, columns.strikePrice)); err != nil {
+		return Instrument{}, fmt.Errorf("strike_price: %w", err)
+	}
+
+	if out.TickSize, err = parseCsvFloat(column(record, columns.tickSize)); err != nil {
+		return Instrument{}, fmt.Errorf("tick_size: %w", err)
+	}
+
+	if out.FreezeQuantity, err = parseCsvInt(column(record, columns.freezeQuantity)); err != nil {
+		return Instrument{}, fmt.Errorf("freeze_quantity: %w", err)
+	}
+
+	if out.IsReserved, err = parseCsvBool(column(record, columns.isReserved)); err != nil {
+		return Instrument{}, fmt.Errorf("is_reserved: %w", err)
+	}
+
+	if out.BuyAllowed, err = parseCsvBool(column(record, columns.buyAllowed)); err != nil {
+		return Instrument{}, fmt.Errorf("buy_allowed: %w", err)
+	}
+
+	if out.SellAllowed, err = parseCsvBool(column(record, columns.sellAllowed)); err != nil {
+		return Instrument{}, fmt.Errorf("sell_allowed: %w", err)
+	}
+
+	return out, nil
+}
+
+// parseCsvInt parses an int like gocsv, dropping any decimal part
+func parseCsvInt(in string) (int, error) {
+	if in == "" {
+		return 0, nil
+	}
+
+	whole, _, _ := strings.Cut(in, ".")
+	out, err := strconv.ParseInt(whole, 0, 64)
+	return int(out), err
+}
+
+func parseCsvFloat(in string) (float32, error) {
+	if in == "" {
+		return 0, nil
+	}
+
+	out, err := strconv.ParseFloat(in, 32)
+	return float32(out), err
+}
+
+// parseCsvBool parses a bool like gocsv, accepting yes and no
+func parseCsvBool(in string) (bool, error) {
+	switch {
+	case strings.EqualFold(in, "yes"):
+		return true, nil
+	case in == "" || strings.EqualFold(in, "no"):
+		return false, nil
+	default:
+		return strconv.ParseBool(in)
+	}
+}
@@ -0,0 +1,514 @@
+package growwapi
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"os"
+	"sync"
+	"time"
+)
+
+// JournalEntryKind represents the kind of JournalEntry
+type JournalEntryKind string
+
+const (
+	// JournalEntryKindIntent - Request about to be sent. Written before the request leaves the process
+	JournalEntryKindIntent JournalEntryKind = "INTENT"
+
+	// JournalEntryKindResult - Response or error received for a request
+	JournalEntryKindResult JournalEntryKind = "RESULT"
+
+	// JournalEntryKindStatus - Status change of an order observed by OrderTracker
+	JournalEntryKindStatus JournalEntryKind = "STATUS"
+)
+
+// JournalOperation represents the order operation a JournalEntry is about
+type JournalOperation string
+
+const (
+	// JournalOperationPlace - Placing an order with OrdersAPI.PlaceOrder
+	JournalOperationPlace JournalOperation = "PLACE"
+
+	// JournalOperationModify - Modifying an order with OrdersAPI.ModifyOrder
+	JournalOperationModify JournalOperation = "MODIFY"
+
+	// JournalOperationCancel - Cancelling an order with OrdersAPI.CancelOrder
+	JournalOperationCancel JournalOperation = "CANCEL"
+
+	// JournalOperationStatus - Status change of an order, see OrderJournal.RecordStatus
+	JournalOperationStatus JournalOperation = "STATUS"
+)
+
+// JournalEntry represents a single line of the OrderJournal
+type JournalEntry struct {
+	// Sequence number of the entry, starting from 1
+	Seq int64 `json:"seq"`
+	// Time at which the entry was written
+	Time time.Time `json:"time"`
+	// Kind of the entry
+	Kind JournalEntryKind `json:"kind"`
+	// Operation the entry is about
+	Operation JournalOperation `json:"operation"`
+	// Tag of the caller, see WithJournalTag
+	Tag string `json:"tag,omitempty"`
+	// Order id generated by Groww for the order, if known
+	GrowwOrderId string `json:"groww_order_id,omitempty"`
+	// Request sent, for JournalEntryKindIntent
+	Request json.RawMessage `json:"request,omitempty"`
+	// Response received, for JournalEntryKindResult and JournalEntryKindStatus
+	Response json.RawMessage `json:"response,omitempty"`
+	// Error received, for JournalEntryKindResult
+	Error *JournalError `json:"error,omitempty"`
+	// Hash of the previous entry, empty for the first one
+	PrevHash string `json:"prev_hash"`
+	// Hash of this entry, computed over PrevHash and all the other fields.
+	// HMAC-SHA256 with the key of the journal, or SHA-256 if the journal has no key
+	Hash string `json:"hash"`
+}
+
+// JournalError represents an error recorded in the OrderJournal
+type JournalError struct {
+	// Error code returned by Groww APIs. Empty for errors which didn't come from Groww APIs
+	Code ErrorCode `json:"code,omitempty"`
+	// Error message
+	Message string `json:"message"`
+	// Metadata returned by Groww APIs
+	Metadata json.RawMessage `json:"metadata,omitempty"`
+}
+
+func newJournalError(err error) (*JournalError, error) {
+	var apiErr Error
+	if !errors.As(err, &apiErr) {
+		return &JournalError{Message: err.Error()}, nil
+	}
+
+	out := &JournalError{Code: apiErr.Code, Message: apiErr.Message}
+	if apiErr.Metadata != nil {
+		msg, err := json.Marshal(apiErr.Metadata)
+		if err != nil {
+			return nil, fmt.Errorf("json.Marshal(metadata): %w", err)
+		}
+		out.Metadata = msg
+	}
+
+	return out, nil
+}
+
+func (e JournalEntry) computeHash(key []byte) (string, error) {
+	e.Hash = ""
+	msg, err := json.Marshal(e)
+	if err != nil {
+		return "", fmt.Errorf("json.Marshal: %w", err)
+	}
+
+	if len(key) == 0 {
+		sum := sha256.Sum256(msg)
+		return hex.EncodeToString(sum[:]), nil
+	}
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write(msg)
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+type journalTagKey struct{}
+
+// WithJournalTag returns a context which tags the order operations performed with it in the OrderJournal.
+// It takes precedence over the tag JournaledOrders was created with.
+func WithJournalTag(ctx context.Context, tag string) context.Context {
+	return context.WithValue(ctx, journalTagKey{}, tag)
+}
+
+// OrderJournal is an append-only log of order intents, broker responses and status changes, written as JSON lines.
+// Each entry carries the hash of the previous one, so that modifications and deletions can be detected with
+// VerifyJournal.
+//
+// Without a key, anyone able to write the file can rewrite the whole chain. Pass a secret key with
+// OrderJournalOptions.Key to chain HMACs instead, and keep OrderJournal.Head outside the file to detect entries
+// being cut off its end.
+//
+// Once a write fails, all subsequent writes fail with the same error, see OrderJournal.Err.
+type OrderJournal struct {
+	key []byte
+
+	mu       sync.Mutex
+	file     *os.File
+	seq      int64
+	lastHash string
+	err      error
+}
+
+// OrderJournalOptions represents the options for OpenOrderJournal
+type OrderJournalOptions struct {
+	// [Optional] Secret key the entries are chained with using HMAC-SHA256. Plain SHA-256 is used if empty.
+	// The same key must be passed to VerifyJournal.
+	Key []byte
+}
+
+// OpenOrderJournal opens the journal at path, creating it if required.
+// An existing journal is verified before new entries are appended to it, and is not opened if verification fails.
+// A partial last line, left behind by a crash in the middle of a write, is truncated before verification.
+func OpenOrderJournal(path string, opts OrderJournalOptions) (*OrderJournal, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("os.OpenFile(%q): %w", path, err)
+	}
+
+	if err := truncateTornRecord(file); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	j := &OrderJournal{key: opts.Key, file: file}
+	for entry, err := range verifyJournal(file, opts.Key) {
+		if err != nil {
+			file.Close()
+			return nil, err
+		}
+
+		j.seq = entry.Seq
+		j.lastHash = entry.Hash
+	}
+
+	return j, nil
+}
+
+// truncateTornRecord removes the bytes after the last newline of the journal. Every entry is written as a single
+// line ending with a newline, so they can only be the remains of an interrupted write.
+func truncateTornRecord(file *os.File) error {
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("file.Stat: %w", err)
+	}
+
+	const chunkSize = 4096
+	buf := make([]byte, chunkSize)
+
+	end := info.Size()
+	for offset := end; offset > 0; {
+		n := min(offset, chunkSize)
+		offset -= n
+
+		if _, err := file.ReadAt(buf[:n], offset); err != nil {
+			return fmt.Errorf("file.ReadAt: %w", err)
+		}
+
+		if i := bytes.LastIndexByte(buf[:n], '\n'); i >= 0 {
+			return truncateJournal(file, end, offset+int64(i)+1)
+		}
+	}
+
+	return truncateJournal(file, end, 0)
+}
+
+func truncateJournal(file *os.File, size, at int64) error {
+	if at == size {
+		return nil
+	}
+
+	if err := file.Truncate(at); err != nil {
+		return fmt.Errorf("file.Truncate: %w", err)
+	}
+
+	if err := file.Sync(); err != nil {
+		return fmt.Errorf("file.Sync: %w", err)
+	}
+
+	return nil
+}
+
+// Head returns the sequence number and hash of the last entry. Stored somewhere else, e.g. with the day's trades,
+// it anchors the chain: a journal whose entry Seq doesn't have this hash has been truncated or rewritten.
+func (j *OrderJournal) Head() (int64, string) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	return j.seq, j.lastHash
+}
+
+// Close closes the journal file
+func (j *OrderJournal) Close() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	return j.file.Close()
+}
+
+// Err returns the first error encountered while writing to the journal
+func (j *OrderJournal) Err() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	return j.err
+}
+
+// Record appends an entry to the journal. Request and response are marshalled as JSON.
+// Seq, Time, PrevHash and Hash of the entry are filled by the journal.
+func (j *OrderJournal) Record(entry JournalEntry, request, response any, err error) error {
+	if request != nil {
+		msg, err := json.Marshal(request)
+		if err != nil {
+			return fmt.Errorf("json.Marshal(request): %w", err)
+		}
+		entry.Request = msg
+	}
+
+	if response != nil {
+		msg, err := json.Marshal(response)
+		if err != nil {
+			return fmt.Errorf("json.Marshal(response): %w", err)
+		}
+		entry.Response = msg
+	}
+
+	if err != nil {
+		journalErr, marshalErr := newJournalError(err)
+		if marshalErr != nil {
+			return marshalErr
+		}
+		entry.Error = journalErr
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.err != nil {
+		return j.err
+	}
+
+	entry.Seq = j.seq + 1
+	entry.Time = time.Now().In(IST)
+	entry.PrevHash = j.lastHash
+
+	hash, hashErr := entry.computeHash(j.key)
+	if hashErr != nil {
+		return hashErr
+	}
+	entry.Hash = hash
+
+	line, marshalErr := json.Marshal(entry)
+	if marshalErr != nil {
+		return fmt.Errorf("json.Marshal(entry): %w", marshalErr)
+	}
+
+	if _, err := j.file.Write(append(line, '\n')); err != nil {
+		j.err = fmt.Errorf("OrderJournal write: %w", err)
+		return j.err
+	}
+
+	if err := j.file.Sync(); err != nil {
+		j.err = fmt.Errorf("OrderJournal sync: %w", err)
+		return j.err
+	}
+
+	j.seq = entry.Seq
+	j.lastHash = entry.Hash
+	return nil
+}
+
+// RecordStatus appends the status change of an order to the journal
+func (j *OrderJournal) RecordStatus(ctx context.Context, update OrderUpdate) error {
+	tag, _ := ctx.Value(journalTagKey{}).(string)
+
+	return j.Record(JournalEntry{
+		Kind:         JournalEntryKindStatus,
+		Operation:    JournalOperationStatus,
+		Tag:          tag,
+		GrowwOrderId: update.GrowwOrderId,
+	}, nil, update, update.Err)
+}
+
+// StatusHandler returns an OrderUpdateHandler for OrderTracker which records the update and then calls next,
+// if it's not nil. Write failures are available from OrderJournal.Err.
+func (j *OrderJournal) StatusHandler(next OrderUpdateHandler) OrderUpdateHandler {
+	return func(ctx context.Context, update OrderUpdate) {
+		_ = j.RecordStatus(ctx, update)
+
+		if next != nil {
+			next(ctx, update)
+		}
+	}
+}
+
+// JournaledOrders is an OrdersAPI recording every PlaceOrder, ModifyOrder and CancelOrder call of the wrapped
+// OrdersAPI in an OrderJournal. The intent is recorded before the request is sent, and the request is not sent if
+// that fails. All other methods are passed through.
+type JournaledOrders struct {
+	OrdersAPI
+	journal *OrderJournal
+	tag     string
+}
+
+// NewJournaledOrders creates a new JournaledOrders. tag is recorded with each entry unless overridden with WithJournalTag
+func NewJournaledOrders(orders OrdersAPI, journal *OrderJournal, tag string) *JournaledOrders {
+	return &JournaledOrders{OrdersAPI: orders, journal: journal, tag: tag}
+}
+
+// PlaceOrder implements OrdersAPI
+func (o *JournaledOrders) PlaceOrder(ctx context.Context, req PlaceOrderRequest) (PlaceOrderResponse, error) {
+	return journaled(ctx, o, JournalOperationPlace, "", req, o.OrdersAPI.PlaceOrder)
+}
+
+// ModifyOrder implements OrdersAPI
+func (o *JournaledOrders) ModifyOrder(ctx context.Context, req ModifyOrderRequest) (ModifyOrderResponse, error) {
+	return journaled(ctx, o, JournalOperationModify, req.GrowwOrderId, req, o.OrdersAPI.ModifyOrder)
+}
+
+// CancelOrder implements OrdersAPI
+func (o *JournaledOrders) CancelOrder(ctx context.Context, req CancelOrderRequest) (CancelOrderResponse, error) {
+	return journaled(ctx, o, JournalOperationCancel, req.GrowwOrderId, req, o.OrdersAPI.CancelOrder)
+}
+
+func (o *JournaledOrders) tagOf(ctx context.Context) string {
+	if tag, ok := ctx.Value(journalTagKey{}).(string); ok {
+		return tag
+	}
+
+	return o.tag
+}
+
+func journaled[Req, Resp any](
+	ctx context.Context,
+	o *JournaledOrders,
+	operation JournalOperation,
+	growwOrderId string,
+	req Req,
+	do func(context.Context, Req) (Resp, error),
+) (Resp, error) {
+	tag := o.tagOf(ctx)
+
+	intent := JournalEntry{Kind: JournalEntryKindIntent, Operation: operation, Tag: tag, GrowwOrderId: growwOrderId}
+	if err := o.journal.Record(intent, req, nil, nil); err != nil {
+		var zero Resp
+		return zero, fmt.Errorf("journal intent: %w", err)
+	}
+
+	resp, err := do(ctx, req)
+
+	result := JournalEntry{Kind: JournalEntryKindResult, Operation: operation, Tag: tag, GrowwOrderId: growwOrderId}
+	if err != nil {
+		// the request was already sent, a failure to journal it must not hide its outcome
+		_ = o.journal.Record(result, nil, nil, err)
+		return resp, err
+	}
+
+	if result.GrowwOrderId == "" {
+		if placed, ok := any(resp).(PlaceOrderResponse); ok {
+			result.GrowwOrderId = placed.GrowwOrderId
+		}
+	}
+
+	_ = o.journal.Record(result, nil, resp, nil)
+	return resp, nil
+}
+
+// ReadJournal returns an iterator over the entries of a journal, without verifying them.
+// Iteration stops at the first line which can't be parsed.
+func ReadJournal(r io.Reader) iter.Seq2[JournalEntry, error] {
+	return func(yield func(JournalEntry, error) bool) {
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+
+		line := 0
+		for scanner.Scan() {
+			line++
+
+			var entry JournalEntry
+			if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+				yield(JournalEntry{}, JournalVerificationError{Line: line, Reason: fmt.Sprintf("json.Unmarshal: %v", err)})
+				return
+			}
+
+			if !yield(entry, nil) {
+				return
+			}
+		}
+
+		if err := scanner.Err(); err != nil {
+			yield(JournalEntry{}, fmt.Errorf("scanner.Err: %w", err))
+		}
+	}
+}
+
+// JournalVerificationError is returned by VerifyJournal when the journal has been tampered with
+type JournalVerificationError struct {
+	// Line number of the offending entry, starting from 1
+	Line int
+	// Reason why verification failed
+	Reason string
+}
+
+func (e JournalVerificationError) Error() string {
+	return fmt.Sprintf("journal line %d: %s", e.Line, e.Reason)
+}
+
+// VerifyJournal verifies the hash chain of a journal written with key, see OrderJournalOptions.Key, and returns the
+// number of valid entries. JournalVerificationError is returned for the first entry which is out of sequence or whose
+// hash doesn't match.
+func VerifyJournal(r io.Reader, key []byte) (int, error) {
+	count := 0
+	for _, err := range verifyJournal(r, key) {
+		if err != nil {
+			return count, err
+		}
+
+		count++
+	}
+
+	return count, nil
+}
+
+func verifyJournal(r io.Reader, key []byte) iter.Seq2[JournalEntry, error] {
+	return func(yield func(JournalEntry, error) bool) {
+		var prev JournalEntry
+		line := 0
+
+		for entry, err := range ReadJournal(r) {
+			line++
+			if err != nil {
+				yield(JournalEntry{}, err)
+				return
+			}
+
+			if err := verifyJournalEntry(prev, entry, line, key); err != nil {
+				yield(JournalEntry{}, err)
+				return
+			}
+
+			if !yield(entry, nil) {
+				return
+			}
+			prev = entry
+		}
+	}
+}
+
+func verifyJournalEntry(prev, entry JournalEntry, line int, key []byte) error {
+	if entry.Seq != prev.Seq+1 {
+		return JournalVerificationError{Line: line, Reason: fmt.Sprintf("expected seq %d, got %d", prev.Seq+1, entry.Seq)}
+	}
+
+	if entry.PrevHash != prev.Hash {
+		return JournalVerificationError{Line: line, Reason: "previous hash does not match"}
+	}
+
+	hash, err := entry.computeHash(key)
+	if err != nil {
+		return JournalVerificationError{Line: line, Reason: err.Error()}
+	}
+
+	if !hmac.Equal([]byte(hash), []byte(entry.Hash)) {
+		return JournalVerificationError{Line: line, Reason: "hash does not match contents"}
+	}
+
+	return nil
+}
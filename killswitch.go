@@ -0,0 +1,326 @@
+package growwapi
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// killSwitchReferencePrefix prefixes the OrderReferenceId of square off orders placed by Client.KillSwitch.
+// The prefix is followed by a random part and a checksum of it, see newKillSwitchReferenceId, so that the
+// reference ids of other orders are not mistaken for square off orders.
+const killSwitchReferencePrefix = "KILLSW-"
+
+// KillSwitchOptions represents the options for Client.KillSwitch
+type KillSwitchOptions struct {
+	// [Optional] Maximum number of requests in flight. Defaults to 4
+	Concurrency int
+	// [Optional] Maximum number of requests started per second. Defaults to 10
+	RequestsPerSecond float64
+	// [Optional] Only cancel open orders, don't square off MIS positions
+	SkipSquareOff bool
+}
+
+// KillSwitchAction represents a single cancellation or square off performed by Client.KillSwitch
+type KillSwitchAction struct {
+	// Order id of the cancelled order, or of the square off order
+	GrowwOrderId string
+	// Reference id of the square off order
+	OrderReferenceId string
+	// Trading Symbol of the instrument as defined by the exchange
+	TradingSymbol string
+	// Stock exchange
+	Exchange Exchange
+	// Segment of the instrument such as CASH, FNO etc.
+	Segment Segment
+	// Transaction type of the order
+	TransactionType TransactionType
+	// Quantity of the cancelled order remaining to be filled, or of the square off order
+	Quantity int
+	// Error in case the action failed
+	Err error
+}
+
+// KillSwitchReport represents the outcome of Client.KillSwitch
+type KillSwitchReport struct {
+	// Orders which were cancelled
+	Cancelled []KillSwitchAction
+	// Orders which could not be cancelled
+	CancelFailed []KillSwitchAction
+	// Square off orders which were placed
+	SquaredOff []KillSwitchAction
+	// Square off orders which could not be placed
+	SquareOffFailed []KillSwitchAction
+}
+
+// Err returns the errors of all failed actions joined together, or nil if everything succeeded
+func (r KillSwitchReport) Err() error {
+	var errs []error
+	for _, a := range r.CancelFailed {
+		errs = append(errs, fmt.Errorf("cancel %s: %w", a.GrowwOrderId, a.Err))
+	}
+
+	for _, a := range r.SquareOffFailed {
+		errs = append(errs, fmt.Errorf("square off %s %s: %w", a.Exchange, a.TradingSymbol, a.Err))
+	}
+
+	return errors.Join(errs...)
+}
+
+// KillSwitch cancels every open order across segments and then squares off the intraday (ProductMis) positions
+// with market orders.
+//
+// Positions are derived from the filled quantities of the day's MIS orders, after the cancellations.
+// Square off orders placed by an earlier call are neither cancelled nor duplicated while they are pending,
+// so calling KillSwitch again is safe. Requests rejected by the API for exceeding its rate limit are retried with
+// exponential backoff.
+//
+// The returned error is only set if the orders could not be listed. Failures of individual cancellations and
+// square offs are part of the report, see KillSwitchReport.Err.
+func (c *Client) KillSwitch(ctx context.Context, opts KillSwitchOptions) (KillSwitchReport, error) {
+	return KillSwitch(ctx, c, opts)
+}
+
+// KillSwitch is Client.KillSwitch for any OrdersAPI, such as PaperClient
+func KillSwitch(ctx context.Context, api OrdersAPI, opts KillSwitchOptions) (KillSwitchReport, error) {
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = 4
+	}
+
+	if opts.RequestsPerSecond == 0 {
+		opts.RequestsPerSecond = 10
+	}
+
+	k := killSwitch{client: api, limiter: newRateLimiter(opts.RequestsPerSecond), concurrency: opts.Concurrency}
+
+	orders, err := k.listOrders(ctx)
+	if err != nil {
+		return k.report, err
+	}
+
+	k.cancelOpenOrders(ctx, orders)
+
+	if opts.SkipSquareOff {
+		return k.report, nil
+	}
+
+	// fills might have happened while cancelling, positions are derived from a fresh snapshot
+	orders, err = k.listOrders(ctx)
+	if err != nil {
+		return k.report, err
+	}
+
+	k.squareOff(ctx, orders)
+	return k.report, nil
+}
+
+type killSwitch struct {
+	client      OrdersAPI
+	limiter     *rateLimiter
+	concurrency int
+
+	mu     sync.Mutex
+	report KillSwitchReport
+}
+
+func (k *killSwitch) listOrders(ctx context.Context) ([]Order, error) {
+	var out []Order
+	for order, err := range AllOrders(ctx, k.client, "") {
+		if err != nil {
+			return nil, fmt.Errorf("AllOrders: %w", err)
+		}
+
+		out = append(out, order)
+	}
+
+	return out, nil
+}
+
+func (k *killSwitch) cancelOpenOrders(ctx context.Context, orders []Order) {
+	var actions []KillSwitchAction
+	for _, order := range orders {
+		if !order.OrderStatus.IsCancellable() || isKillSwitchReferenceId(order.OrderReferenceId) {
+			continue
+		}
+
+		actions = append(actions, KillSwitchAction{
+			GrowwOrderId:    order.GrowwOrderId,
+			TradingSymbol:   order.TradingSymbol,
+			Exchange:        order.Exchange,
+			Segment:         order.Segment,
+			TransactionType: order.TransactionType,
+			Quantity:        order.RemainingQuantity,
+		})
+	}
+
+	k.run(ctx, actions, &k.report.Cancelled, &k.report.CancelFailed, func(ctx context.Context, a KillSwitchAction) (KillSwitchAction, error) {
+		_, err := k.client.CancelOrder(ctx, CancelOrderRequest{Segment: a.Segment, GrowwOrderId: a.GrowwOrderId})
+		return a, err
+	})
+}
+
+type killSwitchPosition struct {
+	exchange      Exchange
+	segment       Segment
+	tradingSymbol string
+}
+
+func (k *killSwitch) squareOff(ctx context.Context, orders []Order) {
+	net := make(map[killSwitchPosition]int)
+	var positions []killSwitchPosition
+
+	for _, order := range orders {
+		if order.Product != ProductMis {
+			continue
+		}
+
+		key := killSwitchPosition{order.Exchange, order.Segment, order.TradingSymbol}
+		if _, ok := net[key]; !ok {
+			positions = append(positions, key)
+		}
+
+		sign := 1
+		if order.TransactionType == TransactionTypeSell {
+			sign = -1
+		}
+
+		net[key] += sign * order.FilledQuantity
+
+		// pending square off orders from an earlier call will flatten the position once filled
+		if isKillSwitchReferenceId(order.OrderReferenceId) && order.OrderStatus.IsOpen() {
+			net[key] += sign * order.RemainingQuantity
+		}
+	}
+
+	var actions []KillSwitchAction
+	for _, key := range positions {
+		quantity := net[key]
+		if quantity == 0 {
+			continue
+		}
+
+		transactionType := TransactionTypeSell
+		if quantity < 0 {
+			transactionType = TransactionTypeBuy
+			quantity = -quantity
+		}
+
+		actions = append(actions, KillSwitchAction{
+			TradingSymbol:   key.tradingSymbol,
+			Exchange:        key.exchange,
+			Segment:         key.segment,
+			TransactionType: transactionType,
+			Quantity:        quantity,
+		})
+	}
+
+	k.run(ctx, actions, &k.report.SquaredOff, &k.report.SquareOffFailed, func(ctx context.Context, a KillSwitchAction) (KillSwitchAction, error) {
+		ref, err := newKillSwitchReferenceId()
+		if err != nil {
+			return a, err
+		}
+
+		a.OrderReferenceId = ref
+		resp, err := k.client.PlaceOrder(ctx, PlaceOrderRequest{
+			TradingSymbol:    a.TradingSymbol,
+			Quantity:         a.Quantity,
+			Validity:         ValidityDay,
+			Exchange:         a.Exchange,
+			Segment:          a.Segment,
+			Product:          ProductMis,
+			OrderType:        OrderTypeMarket,
+			TransactionType:  a.TransactionType,
+			OrderReferenceId: ref,
+		})
+		a.GrowwOrderId = resp.GrowwOrderId
+		return a, err
+	})
+}
+
+// run performs the actions with at most k.concurrency in flight, respecting the rate limit and retrying the ones
+// which are rate limited by the API. Actions are appended to succeeded or failed of k.report once done.
+func (k *killSwitch) run(
+	ctx context.Context,
+	actions []KillSwitchAction,
+	succeeded, failed *[]KillSwitchAction,
+	do func(ctx context.Context, a KillSwitchAction) (KillSwitchAction, error),
+) {
+	sem := make(chan struct{}, k.concurrency)
+	var wg sync.WaitGroup
+
+	record := func(a KillSwitchAction, err error) {
+		k.mu.Lock()
+		defer k.mu.Unlock()
+
+		if err != nil {
+			a.Err = err
+			*failed = append(*failed, a)
+			return
+		}
+
+		*succeeded = append(*succeeded, a)
+	}
+
+	for i, action := range actions {
+		if err := k.limiter.Wait(ctx); err != nil {
+			// ctx is done, the remaining actions are recorded as failed without calling the API
+			for _, a := range actions[i:] {
+				record(a, err)
+			}
+			break
+		}
+
+		sem <- struct{}{}
+		wg.Add(1)
+
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			a, err := retryRateLimited(ctx, func() (KillSwitchAction, error) { return do(ctx, action) })
+			record(a, err)
+		}()
+	}
+
+	wg.Wait()
+}
+
+// newKillSwitchReferenceId returns a reference id made of killSwitchReferencePrefix, 4 random bytes, a hyphen and
+// the checksum of the random bytes, all hex encoded, which is 20 characters: the longest reference id allowed.
+func newKillSwitchReferenceId() (string, error) {
+	b := make([]byte, 4)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("rand.Read: %w", err)
+	}
+
+	return killSwitchReferencePrefix + hex.EncodeToString(b) + "-" + hex.EncodeToString(killSwitchChecksum(b)), nil
+}
+
+// isKillSwitchReferenceId reports whether ref was generated by newKillSwitchReferenceId
+func isKillSwitchReferenceId(ref string) bool {
+	random, checksum, ok := strings.Cut(strings.TrimPrefix(ref, killSwitchReferencePrefix), "-")
+	if !ok || !strings.HasPrefix(ref, killSwitchReferencePrefix) {
+		return false
+	}
+
+	b, err := hex.DecodeString(random)
+	if err != nil || len(b) != 4 {
+		return false
+	}
+
+	want, err := hex.DecodeString(checksum)
+	return err == nil && bytes.Equal(want, killSwitchChecksum(b))
+}
+
+func killSwitchChecksum(random []byte) []byte {
+	sum := sha256.Sum256(append([]byte(killSwitchReferencePrefix), random...))
+	return sum[:2]
+}
@@ -232,7 +232,7 @@ func (c *Client) GetGreeks(ctx context.Context, req GetGreeksRequest) (Greeks, e
 		req.Exchange,
 		req.Underlying,
 		req.TradingSymbol,
-		req.Expiry.Format(time.DateOnly),
+		req.Expiry.In(IST).Format(time.DateOnly),
 	)
 	return doGetRequest[Greeks](ctx, c, destination, nil)
 }
@@ -0,0 +1,209 @@
+package growwapi
+
+import (
+	"context"
+	"fmt"
+	"math"
+)
+
+// ModifyOrderPartialRequest represents the request for Client.ModifyOrderPartial.
+// Fields which are nil are kept as they are on the order.
+type ModifyOrderPartialRequest struct {
+	// Order id generated by Groww for an order
+	GrowwOrderId string
+	// Segment of the instrument such as CASH, FNO etc.
+	Segment Segment
+	// [Optional] New total quantity of the order, including the quantity already filled
+	Quantity *int
+	// [Optional] New price in rupees
+	Price *float32
+	// [Optional] New trigger price in rupees
+	TriggerPrice *float32
+	// [Optional] New order type
+	OrderType *OrderType
+	// [Optional] Minimum price movement of the instrument, see Instrument.TickSize. Prices are validated against it if set
+	TickSize float32
+}
+
+// ModifyOutcome represents the outcome of Client.ModifyOrderPartial
+type ModifyOutcome string
+
+const (
+	// ModifyOutcomeModified - Order was modified
+	ModifyOutcomeModified ModifyOutcome = "MODIFIED"
+
+	// ModifyOutcomeUnchanged - Requested values are the same as the current ones, no modification was sent
+	ModifyOutcomeUnchanged ModifyOutcome = "UNCHANGED"
+
+	// ModifyOutcomeFilled - Order got completely filled before it could be modified
+	ModifyOutcomeFilled ModifyOutcome = "FILLED"
+
+	// ModifyOutcomeNotModifiable - Order is in a status which can't be modified, such as cancelled or rejected
+	ModifyOutcomeNotModifiable ModifyOutcome = "NOT_MODIFIABLE"
+)
+
+// ModifyOrderPartialResult represents the result of Client.ModifyOrderPartial
+type ModifyOrderPartialResult struct {
+	// Outcome of the modification
+	Outcome ModifyOutcome
+	// Order as last fetched from OrdersAPI.GetOrderDetails
+	Order Order
+	// Response of OrdersAPI.ModifyOrder, set for ModifyOutcomeModified
+	Response ModifyOrderResponse
+}
+
+// InvalidModificationError is returned by Client.ModifyOrderPartial when the modified order fails validation.
+// The modification is not sent in that case.
+type InvalidModificationError struct {
+	// Order id generated by Groww for an order
+	GrowwOrderId string
+	// Reason why the modification is invalid
+	Reason string
+}
+
+func (e InvalidModificationError) Error() string {
+	return fmt.Sprintf("invalid modification of %s: %s", e.GrowwOrderId, e.Reason)
+}
+
+// ModifyOrderPartial modifies only the given fields of an order.
+// The current order is fetched with Client.GetOrderDetails and the other fields are sent as they are, except when the
+// order type changes: the price is dropped for MARKET and SL_M, and the trigger price for MARKET and LIMIT.
+// The modified order is validated against the remaining quantity and the tick size before it is sent.
+//
+// If the order fills or gets cancelled while being modified, the result says so with ModifyOutcomeFilled or
+// ModifyOutcomeNotModifiable instead of an error.
+func (c *Client) ModifyOrderPartial(ctx context.Context, req ModifyOrderPartialRequest) (ModifyOrderPartialResult, error) {
+	return ModifyOrderPartial(ctx, c, req)
+}
+
+// ModifyOrderPartial is Client.ModifyOrderPartial for any OrdersAPI
+func ModifyOrderPartial(ctx context.Context, api OrdersAPI, req ModifyOrderPartialRequest) (ModifyOrderPartialResult, error) {
+	order, err := api.GetOrderDetails(ctx, GetOrderDetailsRequest{GrowwOrderId: req.GrowwOrderId, Segment: req.Segment})
+	if err != nil {
+		return ModifyOrderPartialResult{}, fmt.Errorf("GetOrderDetails(%s): %w", req.GrowwOrderId, err)
+	}
+
+	if outcome, ok := unmodifiableOutcome(order); ok {
+		return ModifyOrderPartialResult{Outcome: outcome, Order: order}, nil
+	}
+
+	modify := ModifyOrderRequest{
+		Quantity:     order.Quantity,
+		Price:        order.Price,
+		TriggerPrice: order.TriggerPrice,
+		OrderType:    order.OrderType,
+		Segment:      req.Segment,
+		GrowwOrderId: req.GrowwOrderId,
+	}
+
+	if req.Quantity != nil {
+		modify.Quantity = *req.Quantity
+	}
+
+	if req.Price != nil {
+		modify.Price = *req.Price
+	}
+
+	if req.TriggerPrice != nil {
+		modify.TriggerPrice = *req.TriggerPrice
+	}
+
+	if req.OrderType != nil {
+		modify.OrderType = *req.OrderType
+	}
+
+	// prices of the old order type which don't apply to the new one are not carried over
+	if modify.OrderType != order.OrderType {
+		if !orderTypeHasPrice(modify.OrderType) {
+			modify.Price = 0
+		}
+
+		if !orderTypeHasTriggerPrice(modify.OrderType) {
+			modify.TriggerPrice = 0
+		}
+	}
+
+	if modify.Quantity == order.Quantity && modify.Price == order.Price &&
+		modify.TriggerPrice == order.TriggerPrice && modify.OrderType == order.OrderType {
+		return ModifyOrderPartialResult{Outcome: ModifyOutcomeUnchanged, Order: order}, nil
+	}
+
+	if reason := validateModification(order, modify, req.TickSize); reason != "" {
+		return ModifyOrderPartialResult{Order: order}, InvalidModificationError{GrowwOrderId: req.GrowwOrderId, Reason: reason}
+	}
+
+	resp, err := api.ModifyOrder(ctx, modify)
+	if err == nil {
+		return ModifyOrderPartialResult{Outcome: ModifyOutcomeModified, Order: order, Response: resp}, nil
+	}
+
+	// the order might have filled or been cancelled since it was fetched
+	latest, detailsErr := api.GetOrderDetails(ctx, GetOrderDetailsRequest{GrowwOrderId: req.GrowwOrderId, Segment: req.Segment})
+	if detailsErr == nil {
+		if outcome, ok := unmodifiableOutcome(latest); ok {
+			return ModifyOrderPartialResult{Outcome: outcome, Order: latest}, nil
+		}
+	}
+
+	return ModifyOrderPartialResult{Order: order}, fmt.Errorf("ModifyOrder(%s): %w", req.GrowwOrderId, err)
+}
+
+// orderTypeHasPrice returns true if orders of the type are placed with a limit price
+func orderTypeHasPrice(orderType OrderType) bool {
+	return orderType == OrderTypeLimit || orderType == OrderTypeStopLoss
+}
+
+// orderTypeHasTriggerPrice returns true if orders of the type are placed with a trigger price
+func orderTypeHasTriggerPrice(orderType OrderType) bool {
+	return orderType == OrderTypeStopLoss || orderType == OrderTypeStopLossMarket
+}
+
+func unmodifiableOutcome(order Order) (ModifyOutcome, bool) {
+	switch {
+	case order.OrderStatus.IsFilled():
+		return ModifyOutcomeFilled, true
+	case !order.OrderStatus.IsModifiable():
+		return ModifyOutcomeNotModifiable, true
+	default:
+		return "", false
+	}
+}
+
+func validateModification(order Order, modify ModifyOrderRequest, tickSize float32) string {
+	if modify.Quantity <= order.FilledQuantity {
+		return fmt.Sprintf("quantity %d must be more than the filled quantity %d", modify.Quantity, order.FilledQuantity)
+	}
+
+	switch modify.OrderType {
+	case OrderTypeMarket:
+	case OrderTypeLimit:
+		if modify.Price <= 0 {
+			return "price is required for limit orders"
+		}
+	case OrderTypeStopLoss:
+		if modify.Price <= 0 || modify.TriggerPrice <= 0 {
+			return "price and trigger price are required for stop loss orders"
+		}
+	case OrderTypeStopLossMarket:
+		if modify.TriggerPrice <= 0 {
+			return "trigger price is required for stop loss market orders"
+		}
+	default:
+		return fmt.Sprintf("unknown order type %q", modify.OrderType)
+	}
+
+	if tickSize > 0 {
+		for _, price := range []float32{modify.Price, modify.TriggerPrice} {
+			if !isMultipleOfTick(price, tickSize) {
+				return fmt.Sprintf("price %v is not a multiple of tick size %v", price, tickSize)
+			}
+		}
+	}
+
+	return ""
+}
+
+func isMultipleOfTick(price, tickSize float32) bool {
+	ticks := float64(price) / float64(tickSize)
+	return math.Abs(ticks-math.Round(ticks)) < 1e-3
+}
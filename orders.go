@@ -123,7 +123,8 @@ func (c *Client) CancelOrder(ctx context.Context, req CancelOrderRequest) (Cance
 	return doPostRequest[CancelOrderResponse](ctx, c, destination, req)
 }
 
-// TradesForOrderRequest represents the request data for Client.GetTradesForOrder
+// TradesForOrderRequest represents the request data for Client.GetTradesForOrder.
+// Use Client.AllTradesForOrder to iterate over all the pages.
 //
 // https://groww.in/trade-api/docs/curl/orders#request-schema-3
 type TradesForOrderRequest struct {
@@ -188,7 +189,9 @@ func (c *Client) GetTradesForOrder(ctx context.Context, req TradesForOrderReques
 	return doGetRequest[[]Trade](ctx, c, destination, req)
 }
 
-type orderStatusRequest interface {
+// OrderStatusRequest is implemented by OrderStatusRequestWithGrowwOrderId and OrderStatusRequestWithOrderReferenceId.
+// Use with Client.GetOrderStatus
+type OrderStatusRequest interface {
 	url() string
 	asQueryParam
 }
@@ -219,13 +222,23 @@ type OrderStatusRequestWithOrderReferenceId struct {
 //
 // https://groww.in/trade-api/docs/curl/orders#response-4
 type OrderStatusResponse struct {
-	GrowwOrderId     string `json:"groww_order_id"`
-	OrderStatus      string `json:"order_status"`
-	Remark           string `json:"remark"`
-	FilledQuantity   int    `json:"filled_quantity"`
+	// Order id generated by Groww for an order
+	GrowwOrderId string `json:"groww_order_id"`
+	// Current status of the order
+	OrderStatus OrderStatus `json:"order_status"`
+	// Remark for the order
+	Remark string `json:"remark"`
+	// Quantity of the order which has been executed.
+	FilledQuantity int `json:"filled_quantity"`
+	// User provided reference id to track the status of an order
 	OrderReferenceId string `json:"order_reference_id"`
 }
 
+// Validate returns UnknownOrderStatusError if the API returned an undocumented OrderStatus
+func (o OrderStatusResponse) Validate() error {
+	return o.OrderStatus.Validate()
+}
+
 func (o OrderStatusRequestWithGrowwOrderId) queryParams() url.Values {
 	out := make(url.Values)
 	out.Add("segment", string(o.Segment))
@@ -247,10 +260,11 @@ func (o OrderStatusRequestWithOrderReferenceId) url() string {
 }
 
 // GetOrderStatus The API can be used to check the status of an order using the GrowwOrderId or OrderReferenceId.
-// Use OrderStatusRequestWithGrowwOrderId or OrderStatusRequestWithOrderReferenceId
+// Use OrderStatusRequestWithGrowwOrderId or OrderStatusRequestWithOrderReferenceId.
+// Undocumented statuses are passed through, check them with OrderStatusResponse.Validate.
 //
 // https://groww.in/trade-api/docs/curl/orders#get-order-status
-func (c *Client) GetOrderStatus(ctx context.Context, req orderStatusRequest) (OrderStatusResponse, error) {
+func (c *Client) GetOrderStatus(ctx context.Context, req OrderStatusRequest) (OrderStatusResponse, error) {
 	destination := req.url()
 	return doGetRequest[OrderStatusResponse](ctx, c, destination, req)
 }
@@ -306,12 +320,44 @@ type Order struct {
 	OrderReferenceId string `json:"order_reference_id"`
 }
 
-// ListOrdersRequest represent the request for Client.ListOrders
+// Validate returns UnknownOrderStatusError or UnknownAfterMarketOrderStatusError if the API returned an undocumented
+// status. AmoStatus is optional and only validated when set.
+func (o Order) Validate() error {
+	if err := o.OrderStatus.Validate(); err != nil {
+		return err
+	}
+
+	if o.AmoStatus != "" {
+		return o.AmoStatus.Validate()
+	}
+
+	return nil
+}
+
+// ValidateTransition returns an error if the order can not move from the statuses of previous to its own,
+// see OrderStatus.ValidateTransition and AfterMarketOrderStatus.ValidateTransition
+func (o Order) ValidateTransition(previous Order) error {
+	if err := previous.OrderStatus.ValidateTransition(o.OrderStatus); err != nil {
+		return err
+	}
+
+	if previous.AmoStatus != "" && o.AmoStatus != "" {
+		return previous.AmoStatus.ValidateTransition(o.AmoStatus)
+	}
+
+	return o.Validate()
+}
+
+// ListOrdersRequest represent the request for Client.ListOrders.
+// Use Client.AllOrders to iterate over all the pages.
 //
 // https://groww.in/trade-api/docs/curl/orders#request-schema-6
 type ListOrdersRequest struct {
-	Segment  Segment
-	Page     int
+	// [Optional] Segment of the instrument such as CASH, FNO etc.
+	Segment Segment
+	// [Optional] Page Number
+	Page int
+	// [Optional] Size of the page. Maximum size is 50.
 	PageSize int
 }
 
@@ -335,6 +381,7 @@ func (l ListOrdersRequest) queryParams() url.Values {
 
 // ListOrders : The API can be used to get the history of orders executed for the day.
 // It includes all the orders for the day including open, pending, and executed ones.
+// Undocumented statuses are passed through, check them with Order.Validate.
 //
 // https://groww.in/trade-api/docs/curl/orders#get-order-list
 func (c *Client) ListOrders(ctx context.Context, req ListOrdersRequest) ([]Order, error) {
@@ -360,6 +407,6 @@ func (g GetOrderDetailsRequest) queryParams() url.Values {
 //
 // https://groww.in/trade-api/docs/curl/orders#get-order-details
 func (c *Client) GetOrderDetails(ctx context.Context, req GetOrderDetailsRequest) (Order, error) {
-	destination := fmt.Sprintf(" https://api.groww.in/v1/order/detail/%s", req.GrowwOrderId)
+	destination := fmt.Sprintf("https://api.groww.in/v1/order/detail/%s", req.GrowwOrderId)
 	return doGetRequest[Order](ctx, c, destination, req)
 }
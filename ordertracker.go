@@ -0,0 +1,177 @@
+package growwapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// OrderUpdate represents a change in status or filled quantity of an order tracked by OrderTracker
+type OrderUpdate struct {
+	// Order id generated by Groww for an order
+	GrowwOrderId string
+	// Segment of the instrument such as CASH, FNO etc.
+	Segment Segment
+	// Status of the order before this update. Empty for the first update of an order
+	PreviousStatus OrderStatus
+	// Current status of the order
+	Status OrderStatus
+	// Filled quantity of the order before this update
+	PreviousFilledQuantity int
+	// Current filled quantity of the order
+	FilledQuantity int
+	// Remark for the order
+	Remark string
+	// Set to UnknownOrderStatusError if the API returned an undocumented status,
+	// or to InvalidOrderStatusTransitionError if the order moved between statuses in a way which is not allowed.
+	// The update is delivered regardless, so that handlers can decide how to react.
+	Err error
+}
+
+// OrderUpdateHandler is called by OrderTracker whenever a tracked order changes
+type OrderUpdateHandler func(ctx context.Context, update OrderUpdate)
+
+type trackedOrder struct {
+	segment        Segment
+	status         OrderStatus
+	filledQuantity int
+	handler        OrderUpdateHandler
+}
+
+// OrderTracker polls OrdersAPI.GetOrderStatus for a set of orders and notifies handlers when their status or
+// filled quantity changes. Orders are dropped from tracking once their status is terminal, see OrderStatus.IsTerminal.
+type OrderTracker struct {
+	client   OrdersAPI
+	interval time.Duration
+
+	mu     sync.Mutex
+	orders map[string]*trackedOrder
+}
+
+// NewOrderTracker creates a new OrderTracker polling every interval
+func NewOrderTracker(client OrdersAPI, interval time.Duration) *OrderTracker {
+	if interval <= 0 {
+		interval = time.Second
+	}
+
+	return &OrderTracker{
+		client:   client,
+		interval: interval,
+		orders:   make(map[string]*trackedOrder),
+	}
+}
+
+// Track starts tracking the order. The handler is invoked from OrderTracker.Run on the first poll with the current
+// state of the order, and after that for every change.
+func (t *OrderTracker) Track(growwOrderId string, segment Segment, handler OrderUpdateHandler) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.orders[growwOrderId] = &trackedOrder{segment: segment, handler: handler}
+}
+
+// Untrack stops tracking the order
+func (t *OrderTracker) Untrack(growwOrderId string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.orders, growwOrderId)
+}
+
+// Run polls the tracked orders until ctx is done
+func (t *OrderTracker) Run(ctx context.Context) error {
+	ticker := time.NewTicker(t.interval)
+	defer ticker.Stop()
+
+	for {
+		// errors for individual orders are transient, they are retried on the next tick
+		_ = t.Poll(ctx)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll fetches the status of all tracked orders once and dispatches updates.
+// The returned error joins the errors encountered for individual orders.
+func (t *OrderTracker) Poll(ctx context.Context) error {
+	t.mu.Lock()
+	ids := make([]string, 0, len(t.orders))
+	for id := range t.orders {
+		ids = append(ids, id)
+	}
+	t.mu.Unlock()
+
+	var errs []error
+	for _, id := range ids {
+		if err := t.poll(ctx, id); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("OrderTracker.Poll: %w", errors.Join(errs...))
+	}
+
+	return nil
+}
+
+func (t *OrderTracker) poll(ctx context.Context, id string) error {
+	t.mu.Lock()
+	order, ok := t.orders[id]
+	if !ok {
+		t.mu.Unlock()
+		return nil
+	}
+	segment := order.segment
+	t.mu.Unlock()
+
+	resp, err := t.client.GetOrderStatus(ctx, OrderStatusRequestWithGrowwOrderId{GrowwOrderId: id, Segment: segment})
+	if err != nil {
+		return fmt.Errorf("GetOrderStatus(%s): %w", id, err)
+	}
+
+	status := resp.OrderStatus
+
+	t.mu.Lock()
+	order, ok = t.orders[id]
+	if !ok || (order.status == status && order.filledQuantity == resp.FilledQuantity) {
+		t.mu.Unlock()
+		return nil
+	}
+
+	update := OrderUpdate{
+		GrowwOrderId:           id,
+		Segment:                segment,
+		PreviousStatus:         order.status,
+		Status:                 status,
+		PreviousFilledQuantity: order.filledQuantity,
+		FilledQuantity:         resp.FilledQuantity,
+		Remark:                 resp.Remark,
+	}
+
+	if order.status == "" {
+		update.Err = status.Validate()
+	} else {
+		update.Err = order.status.ValidateTransition(status)
+	}
+
+	order.status = status
+	order.filledQuantity = resp.FilledQuantity
+	if status.IsTerminal() {
+		delete(t.orders, id)
+	}
+	handler := order.handler
+	t.mu.Unlock()
+
+	if handler != nil {
+		handler(ctx, update)
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+package growwapi
+
+import (
+	"context"
+	"iter"
+	"slices"
+)
+
+// maxPageSize is the maximum page size supported by paginated Groww APIs
+const maxPageSize = 50
+
+// paginate returns an iterator over all the items returned by fetch, requesting pages until a page has fewer items
+// than maxPageSize. Iteration stops at the first error.
+func paginate[T any](ctx context.Context, fetch func(ctx context.Context, page, pageSize int) ([]T, error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for page := 0; ; page++ {
+			items, err := fetch(ctx, page, maxPageSize)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			if len(items) < maxPageSize {
+				return
+			}
+		}
+	}
+}
+
+// AllOrders returns an iterator over all the orders of the day for the segment, fetching pages from Client.ListOrders
+// as the iteration proceeds.
+// If segment is empty, orders of SegmentCash and SegmentFno are merged into a single stream ordered by CreatedAt.
+// Since orders are sorted, all pages of both segments are fetched before the first order is yielded.
+func (c *Client) AllOrders(ctx context.Context, segment Segment) iter.Seq2[Order, error] {
+	return AllOrders(ctx, c, segment)
+}
+
+// AllOrders is Client.AllOrders for any OrdersAPI
+func AllOrders(ctx context.Context, api OrdersAPI, segment Segment) iter.Seq2[Order, error] {
+	if segment != "" {
+		return ordersOfSegment(ctx, api, segment)
+	}
+
+	return func(yield func(Order, error) bool) {
+		var out []Order
+
+		for _, s := range []Segment{SegmentCash, SegmentFno} {
+			for order, err := range ordersOfSegment(ctx, api, s) {
+				if err != nil {
+					yield(Order{}, err)
+					return
+				}
+
+				out = append(out, order)
+			}
+		}
+
+		slices.SortStableFunc(out, func(a, b Order) int { return a.CreatedAt.Compare(b.CreatedAt.Time) })
+
+		for _, order := range out {
+			if !yield(order, nil) {
+				return
+			}
+		}
+	}
+}
+
+func ordersOfSegment(ctx context.Context, api OrdersAPI, segment Segment) iter.Seq2[Order, error] {
+	return paginate(ctx, func(ctx context.Context, page, pageSize int) ([]Order, error) {
+		return api.ListOrders(ctx, ListOrdersRequest{Segment: segment, Page: page, PageSize: pageSize})
+	})
+}
+
+// AllTradesForOrder returns an iterator over all the trades of an order, fetching pages from Client.GetTradesForOrder
+// as the iteration proceeds.
+func (c *Client) AllTradesForOrder(ctx context.Context, growwOrderId string, segment Segment) iter.Seq2[Trade, error] {
+	return AllTradesForOrder(ctx, c, growwOrderId, segment)
+}
+
+// AllTradesForOrder is Client.AllTradesForOrder for any OrdersAPI
+func AllTradesForOrder(ctx context.Context, api OrdersAPI, growwOrderId string, segment Segment) iter.Seq2[Trade, error] {
+	return paginate(ctx, func(ctx context.Context, page, pageSize int) ([]Trade, error) {
+		return api.GetTradesForOrder(ctx, TradesForOrderRequest{
+			GrowwOrderId: growwOrderId,
+			Segment:      segment,
+			Page:         page,
+			PageSize:     pageSize,
+		})
+	})
+}
@@ -0,0 +1,495 @@
+package growwapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+	"sync"
+	"time"
+)
+
+// QuoteSource provides quotes to PaperClient and RiskGate. It is implemented by Client and any LiveDataAPI
+type QuoteSource interface {
+	GetQuote(ctx context.Context, req QuoteRequest) (Quote, error)
+}
+
+// PaperClient implements OrdersAPI by simulating fills locally, without placing any order at the exchange.
+//
+// Orders are matched either against live quotes from a QuoteSource using PaperClient.Match, which consumes the market
+// depth of the quote and can hence partially fill orders, or against a replayed price stream using PaperClient.OnPrice,
+// which fills the whole quantity at the given price.
+//
+//   - OrderTypeMarket fills at the best available prices
+//   - OrderTypeLimit fills at prices at or better than Price
+//   - OrderTypeStopLoss and OrderTypeStopLossMarket wait in OrderStatusTriggerPending until the last traded price crosses
+//     TriggerPrice, and then behave as OrderTypeLimit and OrderTypeMarket respectively
+//
+// Orders with a limit price outside the circuit limits of the first quote they are matched against are rejected.
+// Orders which are already working are not rejected when the limits move later on, as on the exchange.
+type PaperClient struct {
+	quotes QuoteSource
+	now    func() time.Time
+
+	mu     sync.Mutex
+	seq    int
+	orders map[string]*paperOrder
+	// order ids in the order they were placed
+	ids []string
+	// segment and order reference id to order id
+	refs map[paperReference]string
+}
+
+type paperOrder struct {
+	order     Order
+	triggered bool
+	// whether the price was checked against circuit limits, which the exchange only does when it accepts an order
+	accepted bool
+	trades   []Trade
+}
+
+type paperReference struct {
+	segment          Segment
+	orderReferenceId string
+}
+
+type paperInstrument struct {
+	exchange      Exchange
+	segment       Segment
+	tradingSymbol string
+}
+
+// NewPaperClient creates a new PaperClient. quotes can be nil if orders are only matched with PaperClient.OnPrice
+func NewPaperClient(quotes QuoteSource) *PaperClient {
+	return &PaperClient{
+		quotes: quotes,
+		now:    time.Now,
+		orders: make(map[string]*paperOrder),
+		refs:   make(map[paperReference]string),
+	}
+}
+
+// PlaceOrder implements OrdersAPI. If PaperClient has a QuoteSource, the order is matched right away.
+// Matching is best effort: the order stays open if the quote can not be fetched.
+func (p *PaperClient) PlaceOrder(ctx context.Context, req PlaceOrderRequest) (PlaceOrderResponse, error) {
+	if err := validatePaperOrder(req.Quantity, req.Price, req.TriggerPrice, req.OrderType); err != nil {
+		return PlaceOrderResponse{}, err
+	}
+
+	if req.TradingSymbol == "" || req.Exchange == "" || req.Segment == "" || req.Product == "" {
+		return PlaceOrderResponse{}, Error{Code: ErrorCodeGA001, Message: "trading symbol, exchange, segment and product are required"}
+	}
+
+	if req.TransactionType != TransactionTypeBuy && req.TransactionType != TransactionTypeSell {
+		return PlaceOrderResponse{}, Error{Code: ErrorCodeGA001, Message: fmt.Sprintf("invalid transaction type %q", req.TransactionType)}
+	}
+
+	p.mu.Lock()
+
+	ref := paperReference{req.Segment, req.OrderReferenceId}
+	if _, ok := p.refs[ref]; ok && req.OrderReferenceId != "" {
+		p.mu.Unlock()
+		return PlaceOrderResponse{}, Error{Code: ErrorCodeGA007, Message: ErrorCodeGA007.Message()}
+	}
+
+	p.seq++
+	now := Time{p.now()}
+	o := &paperOrder{order: Order{
+		GrowwOrderId:      fmt.Sprintf("PAPER%010d", p.seq),
+		TradingSymbol:     req.TradingSymbol,
+		OrderStatus:       OrderStatusAcked,
+		Quantity:          req.Quantity,
+		Price:             req.Price,
+		TriggerPrice:      req.TriggerPrice,
+		RemainingQuantity: req.Quantity,
+		AmoStatus:         AfterMarketOrderStatusNa,
+		Validity:          req.Validity,
+		Exchange:          req.Exchange,
+		OrderType:         req.OrderType,
+		TransactionType:   req.TransactionType,
+		Segment:           req.Segment,
+		Product:           req.Product,
+		CreatedAt:         now,
+		ExchangeTime:      now,
+		TradeDate:         now,
+		OrderReferenceId:  req.OrderReferenceId,
+	}}
+
+	if isStopLossOrderType(req.OrderType) {
+		o.order.OrderStatus = OrderStatusTriggerPending
+	}
+
+	p.orders[o.order.GrowwOrderId] = o
+	p.ids = append(p.ids, o.order.GrowwOrderId)
+	if req.OrderReferenceId != "" {
+		p.refs[ref] = o.order.GrowwOrderId
+	}
+	p.mu.Unlock()
+
+	if p.quotes != nil {
+		// the order is placed regardless, it is matched again by the next PaperClient.Match
+		_ = p.match(ctx, paperInstrument{req.Exchange, req.Segment, req.TradingSymbol})
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return PlaceOrderResponse{
+		GrowwOrderId:     o.order.GrowwOrderId,
+		OrderStatus:      o.order.OrderStatus,
+		OrderReferenceId: o.order.OrderReferenceId,
+		Remark:           o.order.Remark,
+	}, nil
+}
+
+// ModifyOrder implements OrdersAPI
+func (p *PaperClient) ModifyOrder(_ context.Context, req ModifyOrderRequest) (ModifyOrderResponse, error) {
+	if err := validatePaperOrder(req.Quantity, req.Price, req.TriggerPrice, req.OrderType); err != nil {
+		return ModifyOrderResponse{}, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	o, err := p.order(req.GrowwOrderId)
+	if err != nil {
+		return ModifyOrderResponse{}, err
+	}
+
+	if !o.order.OrderStatus.IsModifiable() {
+		return ModifyOrderResponse{}, Error{Code: ErrorCodeGA001, Message: fmt.Sprintf("order in status %s can not be modified", o.order.OrderStatus)}
+	}
+
+	if req.Quantity <= o.order.FilledQuantity {
+		return ModifyOrderResponse{}, Error{Code: ErrorCodeGA001, Message: fmt.Sprintf("quantity must be more than filled quantity %d", o.order.FilledQuantity)}
+	}
+
+	o.order.Quantity = req.Quantity
+	o.order.RemainingQuantity = req.Quantity - o.order.FilledQuantity
+	o.order.Price = req.Price
+	o.order.TriggerPrice = req.TriggerPrice
+	o.order.OrderType = req.OrderType
+
+	o.order.OrderStatus = OrderStatusAcked
+	if isStopLossOrderType(req.OrderType) && !o.triggered {
+		o.order.OrderStatus = OrderStatusTriggerPending
+	}
+
+	return ModifyOrderResponse{GrowwOrderId: o.order.GrowwOrderId, OrderStatus: o.order.OrderStatus}, nil
+}
+
+// CancelOrder implements OrdersAPI
+func (p *PaperClient) CancelOrder(_ context.Context, req CancelOrderRequest) (CancelOrderResponse, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	o, err := p.order(req.GrowwOrderId)
+	if err != nil {
+		return CancelOrderResponse{}, err
+	}
+
+	if !o.order.OrderStatus.IsCancellable() {
+		return CancelOrderResponse{}, Error{Code: ErrorCodeGA001, Message: fmt.Sprintf("order in status %s can not be cancelled", o.order.OrderStatus)}
+	}
+
+	o.order.OrderStatus = OrderStatusCancelled
+	return CancelOrderResponse{GrowwOrderId: o.order.GrowwOrderId, OrderStatus: o.order.OrderStatus}, nil
+}
+
+// GetTradesForOrder implements OrdersAPI
+func (p *PaperClient) GetTradesForOrder(_ context.Context, req TradesForOrderRequest) ([]Trade, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	o, err := p.order(req.GrowwOrderId)
+	if err != nil {
+		return nil, err
+	}
+
+	return paperPage(o.trades, req.Page, req.PageSize), nil
+}
+
+// GetOrderStatus implements OrdersAPI
+func (p *PaperClient) GetOrderStatus(_ context.Context, req OrderStatusRequest) (OrderStatusResponse, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var id string
+	switch r := req.(type) {
+	case OrderStatusRequestWithGrowwOrderId:
+		id = r.GrowwOrderId
+	case OrderStatusRequestWithOrderReferenceId:
+		id = p.refs[paperReference{r.Segment, r.OrderReferenceId}]
+	}
+
+	o, err := p.order(id)
+	if err != nil {
+		return OrderStatusResponse{}, err
+	}
+
+	return OrderStatusResponse{
+		GrowwOrderId:     o.order.GrowwOrderId,
+		OrderStatus:      o.order.OrderStatus,
+		Remark:           o.order.Remark,
+		FilledQuantity:   o.order.FilledQuantity,
+		OrderReferenceId: o.order.OrderReferenceId,
+	}, nil
+}
+
+// ListOrders implements OrdersAPI
+func (p *PaperClient) ListOrders(_ context.Context, req ListOrdersRequest) ([]Order, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var out []Order
+	for _, id := range p.ids {
+		o := p.orders[id]
+		if req.Segment == "" || o.order.Segment == req.Segment {
+			out = append(out, o.order)
+		}
+	}
+
+	return paperPage(out, req.Page, req.PageSize), nil
+}
+
+// GetOrderDetails implements OrdersAPI
+func (p *PaperClient) GetOrderDetails(_ context.Context, req GetOrderDetailsRequest) (Order, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	o, err := p.order(req.GrowwOrderId)
+	if err != nil {
+		return Order{}, err
+	}
+
+	return o.order, nil
+}
+
+// AllOrders is Client.AllOrders for PaperClient
+func (p *PaperClient) AllOrders(ctx context.Context, segment Segment) iter.Seq2[Order, error] {
+	return AllOrders(ctx, p, segment)
+}
+
+// AllTradesForOrder is Client.AllTradesForOrder for PaperClient
+func (p *PaperClient) AllTradesForOrder(ctx context.Context, growwOrderId string, segment Segment) iter.Seq2[Trade, error] {
+	return AllTradesForOrder(ctx, p, growwOrderId, segment)
+}
+
+// Match fetches a quote for every instrument with open orders and matches the orders against it
+func (p *PaperClient) Match(ctx context.Context) error {
+	if p.quotes == nil {
+		return errors.New("PaperClient has no QuoteSource")
+	}
+
+	p.mu.Lock()
+	var instruments []paperInstrument
+	for _, id := range p.ids {
+		o := p.orders[id]
+		instrument := paperInstrument{o.order.Exchange, o.order.Segment, o.order.TradingSymbol}
+		if o.order.OrderStatus.IsOpen() && !slices.Contains(instruments, instrument) {
+			instruments = append(instruments, instrument)
+		}
+	}
+	p.mu.Unlock()
+
+	var errs []error
+	for _, instrument := range instruments {
+		if err := p.match(ctx, instrument); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// OnPrice matches the open orders of the instrument against a traded price, typically from a replayed price stream.
+// Orders crossing the price fill completely at it.
+func (p *PaperClient) OnPrice(exchange Exchange, segment Segment, tradingSymbol string, price float32) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.fill(paperInstrument{exchange, segment, tradingSymbol}, Quote{LastPrice: price})
+}
+
+func (p *PaperClient) match(ctx context.Context, instrument paperInstrument) error {
+	quote, err := p.quotes.GetQuote(ctx, QuoteRequest{
+		Exchange:      instrument.exchange,
+		Segment:       instrument.segment,
+		TradingSymbol: instrument.tradingSymbol,
+	})
+	if err != nil {
+		return fmt.Errorf("GetQuote(%s): %w", instrument.tradingSymbol, err)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.fill(instrument, quote)
+	return nil
+}
+
+// fill matches the open orders of the instrument in the order they were placed. Must be called with p.mu held
+func (p *PaperClient) fill(instrument paperInstrument, quote Quote) {
+	// quantity consumed from each depth level by earlier orders in this round
+	consumedBuy := make([]int, len(quote.Depth.Buy))
+	consumedSell := make([]int, len(quote.Depth.Sell))
+
+	for _, id := range p.ids {
+		o := p.orders[id]
+		if !o.order.OrderStatus.IsOpen() ||
+			(paperInstrument{o.order.Exchange, o.order.Segment, o.order.TradingSymbol}) != instrument {
+			continue
+		}
+
+		buy := o.order.TransactionType == TransactionTypeBuy
+
+		if !o.accepted && quote.UpperCircuitLimit > 0 {
+			if o.order.Price != 0 && o.order.FilledQuantity == 0 &&
+				(o.order.Price > quote.UpperCircuitLimit || o.order.Price < quote.LowerCircuitLimit) {
+				o.order.OrderStatus = OrderStatusRejected
+				o.order.Remark = fmt.Sprintf("price %v outside circuit limits [%v, %v]", o.order.Price, quote.LowerCircuitLimit, quote.UpperCircuitLimit)
+				continue
+			}
+
+			o.accepted = true
+		}
+
+		if o.order.OrderStatus == OrderStatusTriggerPending {
+			if quote.LastPrice == 0 ||
+				(buy && quote.LastPrice < o.order.TriggerPrice) ||
+				(!buy && quote.LastPrice > o.order.TriggerPrice) {
+				continue
+			}
+
+			o.triggered = true
+			o.order.OrderStatus = OrderStatusAcked
+		}
+
+		// market orders have no limit price
+		limit := o.order.Price
+		if o.order.OrderType == OrderTypeMarket || o.order.OrderType == OrderTypeStopLossMarket {
+			limit = 0
+		}
+
+		book, consumed := quote.Depth.Sell, consumedSell
+		if !buy {
+			book, consumed = quote.Depth.Buy, consumedBuy
+		}
+
+		if len(book) == 0 {
+			// no depth, the whole quantity trades at the last traded price
+			if quote.LastPrice == 0 || (limit != 0 && ((buy && quote.LastPrice > limit) || (!buy && quote.LastPrice < limit))) {
+				continue
+			}
+
+			p.trade(o, quote.LastPrice, o.order.RemainingQuantity)
+			continue
+		}
+
+		for i, level := range book {
+			if o.order.RemainingQuantity == 0 {
+				break
+			}
+
+			if limit != 0 && ((buy && level.Price > limit) || (!buy && level.Price < limit)) {
+				break
+			}
+
+			quantity := min(level.Quantity-consumed[i], o.order.RemainingQuantity)
+			if quantity <= 0 {
+				continue
+			}
+
+			consumed[i] += quantity
+			p.trade(o, level.Price, quantity)
+		}
+	}
+}
+
+// trade records a fill on the order. Must be called with p.mu held
+func (p *PaperClient) trade(o *paperOrder, price float32, quantity int) {
+	if quantity <= 0 {
+		return
+	}
+
+	filled := o.order.FilledQuantity + quantity
+	o.order.AverageFillPrice = (o.order.AverageFillPrice*float32(o.order.FilledQuantity) + price*float32(quantity)) / float32(filled)
+	o.order.FilledQuantity = filled
+	o.order.RemainingQuantity -= quantity
+
+	status := OrderStatusAcked
+	if o.order.RemainingQuantity == 0 {
+		status = OrderStatusExecuted
+	}
+	o.order.OrderStatus = status
+
+	now := Time{p.now()}
+	o.trades = append(o.trades, Trade{
+		Price:           price,
+		Quantity:        quantity,
+		GrowwOrderId:    o.order.GrowwOrderId,
+		GrowwTradeId:    fmt.Sprintf("%s-%d", o.order.GrowwOrderId, len(o.trades)+1),
+		TradeStatus:     status,
+		TradingSymbol:   o.order.TradingSymbol,
+		Exchange:        o.order.Exchange,
+		Segment:         o.order.Segment,
+		Product:         o.order.Product,
+		TransactionType: o.order.TransactionType,
+		CreatedAt:       now,
+		TradeDateTime:   now,
+	})
+}
+
+// order returns the order with given id. Must be called with p.mu held
+func (p *PaperClient) order(id string) (*paperOrder, error) {
+	o, ok := p.orders[id]
+	if !ok {
+		return nil, Error{Code: ErrorCodeGA004, Message: ErrorCodeGA004.Message()}
+	}
+
+	return o, nil
+}
+
+func validatePaperOrder(quantity int, price, triggerPrice float32, orderType OrderType) error {
+	if quantity <= 0 {
+		return Error{Code: ErrorCodeGA001, Message: "quantity must be positive"}
+	}
+
+	switch orderType {
+	case OrderTypeMarket:
+	case OrderTypeLimit:
+		if price <= 0 {
+			return Error{Code: ErrorCodeGA001, Message: "price is required for limit orders"}
+		}
+	case OrderTypeStopLoss:
+		if price <= 0 || triggerPrice <= 0 {
+			return Error{Code: ErrorCodeGA001, Message: "price and trigger price are required for stop loss orders"}
+		}
+	case OrderTypeStopLossMarket:
+		if triggerPrice <= 0 {
+			return Error{Code: ErrorCodeGA001, Message: "trigger price is required for stop loss market orders"}
+		}
+	default:
+		return Error{Code: ErrorCodeGA001, Message: fmt.Sprintf("invalid order type %q", orderType)}
+	}
+
+	return nil
+}
+
+func isStopLossOrderType(orderType OrderType) bool {
+	return orderType == OrderTypeStopLoss || orderType == OrderTypeStopLossMarket
+}
+
+func paperPage[T any](items []T, page, pageSize int) []T {
+	if pageSize <= 0 {
+		pageSize = maxPageSize
+	}
+
+	start := page * pageSize
+	if start >= len(items) {
+		return nil
+	}
+
+	return slices.Clone(items[start:min(start+pageSize, len(items))])
+}
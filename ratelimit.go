@@ -0,0 +1,93 @@
+package growwapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// rateLimitRetries is the number of times a rate limited request is retried
+	rateLimitRetries = 5
+	// rateLimitBackoff is the delay before the first retry of a rate limited request, doubled for every retry
+	rateLimitBackoff = 500 * time.Millisecond
+)
+
+// rateLimiter spaces out calls so that at most a fixed number of them start every second.
+// A nil rateLimiter does not limit.
+type rateLimiter struct {
+	interval time.Duration
+
+	mu   sync.Mutex
+	next time.Time
+}
+
+// newRateLimiter creates a rateLimiter allowing perSecond calls every second. Returns nil if perSecond is not positive
+func newRateLimiter(perSecond float64) *rateLimiter {
+	if perSecond <= 0 {
+		return nil
+	}
+
+	return &rateLimiter{interval: time.Duration(float64(time.Second) / perSecond)}
+}
+
+// Wait blocks until the next call is allowed or ctx is done
+func (r *rateLimiter) Wait(ctx context.Context) error {
+	if r == nil {
+		return ctx.Err()
+	}
+
+	r.mu.Lock()
+	now := time.Now()
+	at := r.next
+	if at.Before(now) {
+		at = now
+	}
+	r.next = at.Add(r.interval)
+	r.mu.Unlock()
+
+	delay := time.Until(at)
+	if delay <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// isRateLimited reports whether err is the API rejecting a request with http.StatusTooManyRequests
+func isRateLimited(err error) bool {
+	var apiErr Error
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusTooManyRequests
+}
+
+// retryRateLimited calls do until it is not rate limited, backing off exponentially between the calls.
+// The error of the last call is returned once rateLimitRetries are exhausted.
+func retryRateLimited[T any](ctx context.Context, do func() (T, error)) (T, error) {
+	backoff := rateLimitBackoff
+	for retry := 0; ; retry++ {
+		out, err := do()
+		if retry == rateLimitRetries || !isRateLimited(err) {
+			return out, err
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return out, err
+		case <-timer.C:
+		}
+
+		backoff *= 2
+	}
+}
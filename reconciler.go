@@ -0,0 +1,274 @@
+package growwapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+// OrderEventType represents the kind of change detected by Reconciler between two snapshots of the order book
+type OrderEventType string
+
+const (
+	// OrderEventTypeNew - Order was not present in the previous snapshot
+	OrderEventTypeNew OrderEventType = "NEW"
+
+	// OrderEventTypeStatusChanged - OrderStatus of the order changed
+	OrderEventTypeStatusChanged OrderEventType = "STATUS_CHANGED"
+
+	// OrderEventTypeFillChanged - FilledQuantity of the order changed
+	OrderEventTypeFillChanged OrderEventType = "FILL_CHANGED"
+
+	// OrderEventTypeModified - Quantity, Price, TriggerPrice or OrderType of the order changed
+	OrderEventTypeModified OrderEventType = "MODIFIED"
+
+	// OrderEventTypeRemoved - Order present in the previous snapshot is missing from the latest one
+	OrderEventTypeRemoved OrderEventType = "REMOVED"
+)
+
+// OrderEvent represents a change in the order book detected by Reconciler
+type OrderEvent struct {
+	// Type of the change
+	Type OrderEventType
+	// Order as seen in the latest snapshot. Same as Previous for OrderEventTypeRemoved
+	Order Order
+	// Order as seen in the previous snapshot. Zero value for OrderEventTypeNew
+	Previous Order
+	// Set if the API returned an undocumented status, see Order.Validate, or if the order moved between statuses
+	// in a way which is not allowed, see Order.ValidateTransition. The event is delivered regardless.
+	Err error
+}
+
+// IntendedOrder represents an order the local system placed or is about to place
+type IntendedOrder struct {
+	// Request the order was placed with
+	Request PlaceOrderRequest
+	// Time at which the order was intended
+	IntendedAt time.Time
+}
+
+// ReconciliationReport represents the result of Reconciler.Reconcile
+type ReconciliationReport struct {
+	// Orders present at the broker which were not intended locally, i.e. placed outside the system
+	Orphaned []Order
+	// Intended orders which are not present at the broker
+	Missing []IntendedOrder
+}
+
+// Reconciler maintains a local order book by periodically pulling OrdersAPI.ListOrders for all segments.
+// Orders are keyed by GrowwOrderId and every sync emits the differences from the previous snapshot as OrderEvent.
+//
+// Orders placed by the local system can be registered with Reconciler.Intend and matched against the order book
+// by OrderReferenceId using Reconciler.Reconcile.
+type Reconciler struct {
+	client   OrdersAPI
+	interval time.Duration
+
+	mu       sync.Mutex
+	book     map[string]Order
+	intended map[string]IntendedOrder
+	// time the snapshot of the book was pulled at, zero until the first successful sync
+	syncedAt time.Time
+}
+
+// NewReconciler creates a new Reconciler syncing every interval when run with Reconciler.Run
+func NewReconciler(client OrdersAPI, interval time.Duration) *Reconciler {
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+
+	return &Reconciler{
+		client:   client,
+		interval: interval,
+		book:     make(map[string]Order),
+		intended: make(map[string]IntendedOrder),
+	}
+}
+
+// Run syncs the order book every interval until ctx is done, passing the detected events to handler.
+// A failed sync leaves the book untouched and is passed to onError, if not nil; changes are picked up by the next one.
+func (r *Reconciler) Run(
+	ctx context.Context,
+	handler func(ctx context.Context, event OrderEvent),
+	onError func(ctx context.Context, err error),
+) error {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		events, err := r.Sync(ctx)
+		if err != nil && onError != nil && ctx.Err() == nil {
+			onError(ctx, err)
+		}
+
+		for _, event := range events {
+			handler(ctx, event)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sync pulls a fresh snapshot of the orders, replaces the local order book and returns the differences from the
+// previous snapshot. Orders which are no longer returned by the API are reported as OrderEventTypeRemoved.
+func (r *Reconciler) Sync(ctx context.Context) ([]OrderEvent, error) {
+	// orders placed while the pages are pulled might be missing, so the snapshot is as old as its start
+	syncedAt := time.Now()
+
+	var snapshot []Order
+	for order, err := range AllOrders(ctx, r.client, "") {
+		if err != nil {
+			return nil, fmt.Errorf("AllOrders: %w", err)
+		}
+
+		snapshot = append(snapshot, order)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	book := make(map[string]Order, len(snapshot))
+	var events []OrderEvent
+
+	for _, order := range snapshot {
+		book[order.GrowwOrderId] = order
+
+		previous, ok := r.book[order.GrowwOrderId]
+		if !ok {
+			events = append(events, OrderEvent{Type: OrderEventTypeNew, Order: order, Err: order.Validate()})
+			continue
+		}
+
+		changes := diffOrders(previous, order)
+		if len(changes) > 0 {
+			err := order.ValidateTransition(previous)
+			for i := range changes {
+				changes[i].Err = err
+			}
+		}
+
+		events = append(events, changes...)
+	}
+
+	var removed []Order
+	for id, previous := range r.book {
+		if _, ok := book[id]; !ok {
+			removed = append(removed, previous)
+		}
+	}
+
+	slices.SortStableFunc(removed, func(a, b Order) int { return a.CreatedAt.Compare(b.CreatedAt.Time) })
+	for _, previous := range removed {
+		events = append(events, OrderEvent{Type: OrderEventTypeRemoved, Order: previous, Previous: previous})
+	}
+
+	r.book = book
+	r.syncedAt = syncedAt
+	return events, nil
+}
+
+func diffOrders(previous, current Order) []OrderEvent {
+	var out []OrderEvent
+
+	if previous.OrderStatus != current.OrderStatus {
+		out = append(out, OrderEvent{Type: OrderEventTypeStatusChanged, Order: current, Previous: previous})
+	}
+
+	if previous.FilledQuantity != current.FilledQuantity {
+		out = append(out, OrderEvent{Type: OrderEventTypeFillChanged, Order: current, Previous: previous})
+	}
+
+	if previous.Quantity != current.Quantity ||
+		previous.Price != current.Price ||
+		previous.TriggerPrice != current.TriggerPrice ||
+		previous.OrderType != current.OrderType {
+		out = append(out, OrderEvent{Type: OrderEventTypeModified, Order: current, Previous: previous})
+	}
+
+	return out
+}
+
+// Book returns the orders in the local order book as of the last sync, ordered by creation time
+func (r *Reconciler) Book() []Order {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	out := make([]Order, 0, len(r.book))
+	for _, order := range r.book {
+		out = append(out, order)
+	}
+
+	slices.SortStableFunc(out, func(a, b Order) int { return a.CreatedAt.Compare(b.CreatedAt.Time) })
+	return out
+}
+
+// Order returns the order with given GrowwOrderId from the local order book
+func (r *Reconciler) Order(growwOrderId string) (Order, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	order, ok := r.book[growwOrderId]
+	return order, ok
+}
+
+// Intend registers an order placed by the local system. Orders are matched by OrderReferenceId,
+// which hence must be set on the request.
+func (r *Reconciler) Intend(req PlaceOrderRequest) error {
+	if req.OrderReferenceId == "" {
+		return errors.New("OrderReferenceId is required to reconcile an order")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.intended[req.OrderReferenceId] = IntendedOrder{Request: req, IntendedAt: time.Now()}
+	return nil
+}
+
+// Forget removes an order registered with Reconciler.Intend
+func (r *Reconciler) Forget(orderReferenceId string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.intended, orderReferenceId)
+}
+
+// Reconcile compares the order book as of the last sync with the intended orders.
+// Intended orders registered after the last sync, or less than grace before it, are not reported as missing, since
+// they might still have been in flight. Nothing is reported as missing before the first sync.
+func (r *Reconciler) Reconcile(grace time.Duration) ReconciliationReport {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var report ReconciliationReport
+	seen := make(map[string]bool, len(r.book))
+
+	for _, order := range r.book {
+		if _, ok := r.intended[order.OrderReferenceId]; ok && order.OrderReferenceId != "" {
+			seen[order.OrderReferenceId] = true
+			continue
+		}
+
+		report.Orphaned = append(report.Orphaned, order)
+	}
+
+	cutoff := r.syncedAt.Add(-grace)
+	for ref, intended := range r.intended {
+		if r.syncedAt.IsZero() || seen[ref] || intended.IntendedAt.After(cutoff) {
+			continue
+		}
+
+		report.Missing = append(report.Missing, intended)
+	}
+
+	slices.SortStableFunc(report.Orphaned, func(a, b Order) int { return a.CreatedAt.Compare(b.CreatedAt.Time) })
+	slices.SortStableFunc(report.Missing, func(a, b IntendedOrder) int { return a.IntendedAt.Compare(b.IntendedAt) })
+	return report
+}
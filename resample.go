@@ -0,0 +1,112 @@
+package growwapi
+
+import (
+	"time"
+)
+
+// Duration returns the length of a candle of the interval. Months have no fixed length, so false is returned for
+// CandleInterval1Month and unknown intervals.
+func (i CandleInterval) Duration() (time.Duration, bool) {
+	switch i {
+	case CandleInterval1Min:
+		return time.Minute, true
+	case CandleInterval2Min:
+		return 2 * time.Minute, true
+	case CandleInterval3Min:
+		return 3 * time.Minute, true
+	case CandleInterval5Min:
+		return 5 * time.Minute, true
+	case CandleInterval10Min:
+		return 10 * time.Minute, true
+	case CandleInterval15Min:
+		return 15 * time.Minute, true
+	case CandleInterval30Min:
+		return 30 * time.Minute, true
+	case CandleInterval1Hour:
+		return time.Hour, true
+	case CandleInterval4Hour:
+		return 4 * time.Hour, true
+	case CandleInterval1Day:
+		return 24 * time.Hour, true
+	case CandleInterval1Week:
+		return 7 * 24 * time.Hour, true
+	default:
+		return 0, false
+	}
+}
+
+// marketOpen is the time of day candles are aligned to by Resample
+var marketOpen = clock{9, 15}
+
+// Resample aggregates candles, sorted by timestamp, into candles of the given interval, e.g. 1 minute candles into
+// 7 or 45 minute ones. Candles are bucketed per IST date, starting at market open (09:15 IST) rather than at the
+// hour, so the first 45 minute candle of a day is 09:15 to 10:00. The last candle of a day is cut at the end of the
+// date, and candles before 09:15 fall in buckets counted back from it.
+//
+// Aggregated candles are timestamped at the start of their bucket, and have the open of their first candle, the
+// close of their last one, the highest high and lowest low, the sum of the volumes and the last known open interest.
+// Buckets without candles are skipped.
+func Resample(candles []Candle, interval time.Duration) []Candle {
+	if interval <= 0 {
+		return nil
+	}
+
+	return aggregateCandles(candles, func(t time.Time) time.Time {
+		t = t.In(IST)
+		year, month, day := t.Date()
+		open := marketOpen.on(year, month, day)
+
+		buckets := t.Sub(open) / interval
+		if t.Before(open) && t.Sub(open)%interval != 0 {
+			buckets--
+		}
+
+		start := open.Add(buckets * interval)
+		return maxTime(start, startOfDate(t))
+	})
+}
+
+// ResampleDaily aggregates intraday candles, sorted by timestamp, into a candle per IST date, timestamped at midnight
+// IST like the 1 day candles of Client.GetHistoricalCandles. See Resample for how candles are aggregated.
+func ResampleDaily(candles []Candle) []Candle {
+	return aggregateCandles(candles, startOfDate)
+}
+
+// aggregateCandles merges consecutive candles falling in the same bucket, which is identified by its start time
+func aggregateCandles(candles []Candle, bucket func(time.Time) time.Time) []Candle {
+	var out []Candle
+	var current time.Time
+
+	for _, candle := range candles {
+		start := bucket(candle.Timestamp.Time)
+
+		if len(out) == 0 || !start.Equal(current) {
+			current = start
+			aggregated := candle
+			aggregated.Timestamp = Time{start}
+			aggregated.OpenInterest = cloneOpenInterest(candle.OpenInterest)
+			out = append(out, aggregated)
+			continue
+		}
+
+		last := &out[len(out)-1]
+		last.High = max(last.High, candle.High)
+		last.Low = min(last.Low, candle.Low)
+		last.Close = candle.Close
+		last.Volume += candle.Volume
+		if candle.OpenInterest != nil {
+			last.OpenInterest = cloneOpenInterest(candle.OpenInterest)
+		}
+	}
+
+	return out
+}
+
+func cloneOpenInterest(oi *float32) *float32 {
+	if oi == nil {
+		return nil
+	}
+
+	out := *oi
+	return &out
+}
@@ -0,0 +1,420 @@
+package growwapi
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+	"time"
+)
+
+// RiskRule identifies the check of RiskGate which rejected an order
+type RiskRule string
+
+const (
+	// RiskRuleSegment - Segment of the order is not allowed
+	RiskRuleSegment RiskRule = "SEGMENT"
+
+	// RiskRuleProduct - Product of the order is not allowed
+	RiskRuleProduct RiskRule = "PRODUCT"
+
+	// RiskRuleQuantity - Quantity of the order exceeds the maximum allowed for the symbol
+	RiskRuleQuantity RiskRule = "QUANTITY"
+
+	// RiskRuleOrderNotional - Notional value of the order exceeds the maximum allowed per order
+	RiskRuleOrderNotional RiskRule = "ORDER_NOTIONAL"
+
+	// RiskRuleDailyNotional - Notional value of the order would take the day's total above the maximum allowed
+	RiskRuleDailyNotional RiskRule = "DAILY_NOTIONAL"
+
+	// RiskRuleOpenOrders - Number of open orders is already at the maximum allowed
+	RiskRuleOpenOrders RiskRule = "OPEN_ORDERS"
+
+	// RiskRulePriceBand - Price of the order is outside the circuit limits of the instrument
+	RiskRulePriceBand RiskRule = "PRICE_BAND"
+
+	// RiskRuleLtpDeviation - Price of the order deviates from the last traded price more than allowed
+	RiskRuleLtpDeviation RiskRule = "LTP_DEVIATION"
+
+	// RiskRuleDailyLoss - Loss for the day has reached the maximum allowed
+	RiskRuleDailyLoss RiskRule = "DAILY_LOSS"
+)
+
+// RiskError is returned by RiskGate when an order is rejected by a pre-trade check.
+// Rejected orders never reach the order APIs.
+type RiskError struct {
+	// Rule which rejected the order
+	Rule RiskRule
+	// Details about the rejection
+	Message string
+}
+
+func (e RiskError) Error() string {
+	return fmt.Sprintf("[%s] %s", e.Rule, e.Message)
+}
+
+// RiskLimits represents the limits enforced by RiskGate. Limits which are not set are not enforced.
+type RiskLimits struct {
+	// [Optional] Maximum quantity per order by trading symbol
+	MaxQuantity map[string]int
+	// [Optional] Maximum quantity per order for trading symbols not present in MaxQuantity
+	DefaultMaxQuantity int
+	// [Optional] Maximum notional value in rupees of a single order
+	MaxOrderNotional float64
+	// [Optional] Maximum notional value in rupees of all orders placed in a day
+	MaxDailyNotional float64
+	// [Optional] Maximum number of open orders across segments
+	MaxOpenOrders int
+	// [Optional] Products orders can be placed with
+	AllowedProducts []Product
+	// [Optional] Segments orders can be placed in
+	AllowedSegments []Segment
+	// [Optional] Reject orders priced outside Quote.LowerCircuitLimit and Quote.UpperCircuitLimit
+	CheckPriceBand bool
+	// [Optional] Maximum deviation of the order price from the last traded price, as a fraction. 0.05 means 5%
+	MaxLtpDeviation float64
+	// [Optional] Maximum loss in rupees for the day, see RiskGate.SetDailyPnl
+	MaxDailyLoss float64
+}
+
+// RiskGate is an OrdersAPI enforcing RiskLimits in front of OrdersAPI.PlaceOrder and OrdersAPI.ModifyOrder
+// of the wrapped OrdersAPI. Orders violating a limit are rejected with RiskError without reaching the wrapped OrdersAPI.
+// All other methods are passed through.
+//
+// Quotes are fetched from the QuoteSource when a check needs the circuit limits or the last traded price,
+// and open orders are counted with OrdersAPI.ListOrders when RiskLimits.MaxOpenOrders is set.
+// Orders placed through the gate while the orders are listed are counted as open as well, so concurrent orders
+// can't exceed the limit together.
+//
+// Notional value is reserved before an order reaches the wrapped OrdersAPI and released if the call fails,
+// so the lock of the gate is never held across API calls. Daily counters reset at midnight IST.
+type RiskGate struct {
+	OrdersAPI
+	quotes QuoteSource
+	limits RiskLimits
+
+	mu            sync.Mutex
+	day           string
+	dailyNotional float64
+	dailyPnl      float64
+	// number of orders placed successfully, used to count the ones missing from a listing of open orders
+	placed int
+	// number of orders which passed the checks and are being placed
+	pending int
+	// orders placed through the gate, used to check modifications
+	orders map[string]PlaceOrderRequest
+}
+
+// NewRiskGate creates a new RiskGate wrapping orders
+func NewRiskGate(orders OrdersAPI, quotes QuoteSource, limits RiskLimits) *RiskGate {
+	return &RiskGate{
+		OrdersAPI: orders,
+		quotes:    quotes,
+		limits:    limits,
+		orders:    make(map[string]PlaceOrderRequest),
+	}
+}
+
+// SetDailyPnl updates the profit and loss of the day in rupees, checked against RiskLimits.MaxDailyLoss.
+// Losses are negative.
+func (g *RiskGate) SetDailyPnl(pnl float64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.rollover()
+	g.dailyPnl = pnl
+}
+
+// DailyNotional returns the notional value in rupees of all orders placed through the gate today
+func (g *RiskGate) DailyNotional() float64 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.rollover()
+	return g.dailyNotional
+}
+
+// PlaceOrder checks the order against the limits and places it with the wrapped OrdersAPI
+func (g *RiskGate) PlaceOrder(ctx context.Context, req PlaceOrderRequest) (PlaceOrderResponse, error) {
+	notional, err := g.check(ctx, req)
+	if err != nil {
+		return PlaceOrderResponse{}, err
+	}
+
+	g.mu.Lock()
+	placed := g.placed
+	g.mu.Unlock()
+
+	open := 0
+	if g.limits.MaxOpenOrders > 0 {
+		if open, err = g.openOrders(ctx); err != nil {
+			return PlaceOrderResponse{}, err
+		}
+	}
+
+	g.mu.Lock()
+	// orders placed since the listing started, or still being placed, might be missing from it
+	open += g.placed - placed + g.pending
+	if g.limits.MaxOpenOrders > 0 && open >= g.limits.MaxOpenOrders {
+		g.mu.Unlock()
+		return PlaceOrderResponse{}, RiskError{RiskRuleOpenOrders, fmt.Sprintf(
+			"%d open orders, maximum is %d", open, g.limits.MaxOpenOrders,
+		)}
+	}
+
+	day, err := g.reserve(notional)
+	if err != nil {
+		g.mu.Unlock()
+		return PlaceOrderResponse{}, err
+	}
+	g.pending++
+	g.mu.Unlock()
+
+	resp, err := g.OrdersAPI.PlaceOrder(ctx, req)
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.pending--
+	if err != nil {
+		g.release(day, notional)
+		return resp, err
+	}
+
+	g.placed++
+	g.orders[resp.GrowwOrderId] = req
+	return resp, nil
+}
+
+// ModifyOrder checks the modified order against the limits and modifies it with the wrapped OrdersAPI.
+// Orders not placed through the gate are fetched with OrdersAPI.GetOrderDetails.
+func (g *RiskGate) ModifyOrder(ctx context.Context, req ModifyOrderRequest) (ModifyOrderResponse, error) {
+	g.mu.Lock()
+	original, ok := g.orders[req.GrowwOrderId]
+	g.mu.Unlock()
+
+	if !ok {
+		order, err := g.OrdersAPI.GetOrderDetails(ctx, GetOrderDetailsRequest{GrowwOrderId: req.GrowwOrderId, Segment: req.Segment})
+		if err != nil {
+			return ModifyOrderResponse{}, fmt.Errorf("GetOrderDetails(%s): %w", req.GrowwOrderId, err)
+		}
+
+		original = PlaceOrderRequest{
+			TradingSymbol:    order.TradingSymbol,
+			Quantity:         order.Quantity,
+			Price:            order.Price,
+			TriggerPrice:     order.TriggerPrice,
+			Validity:         order.Validity,
+			Exchange:         order.Exchange,
+			Segment:          order.Segment,
+			Product:          order.Product,
+			OrderType:        order.OrderType,
+			TransactionType:  order.TransactionType,
+			OrderReferenceId: order.OrderReferenceId,
+		}
+	}
+
+	modified := original
+	modified.Quantity = req.Quantity
+	modified.Price = req.Price
+	modified.TriggerPrice = req.TriggerPrice
+	modified.OrderType = req.OrderType
+
+	notional, err := g.check(ctx, modified)
+	if err != nil {
+		return ModifyOrderResponse{}, err
+	}
+
+	// only the increase in notional value counts towards the daily limit
+	var previousNotional float64
+	if g.limits.MaxDailyNotional > 0 {
+		if previousNotional, err = g.notional(ctx, original); err != nil {
+			return ModifyOrderResponse{}, err
+		}
+	}
+
+	increase := max(notional-previousNotional, 0)
+
+	g.mu.Lock()
+	day, err := g.reserve(increase)
+	g.mu.Unlock()
+	if err != nil {
+		return ModifyOrderResponse{}, err
+	}
+
+	resp, err := g.OrdersAPI.ModifyOrder(ctx, req)
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if err != nil {
+		g.release(day, increase)
+		return resp, err
+	}
+
+	g.orders[req.GrowwOrderId] = modified
+	return resp, nil
+}
+
+// check runs all the checks which don't need the lock and returns the notional value of the order
+func (g *RiskGate) check(ctx context.Context, req PlaceOrderRequest) (float64, error) {
+	l := g.limits
+
+	if len(l.AllowedSegments) > 0 && !slices.Contains(l.AllowedSegments, req.Segment) {
+		return 0, RiskError{RiskRuleSegment, fmt.Sprintf("segment %s is not allowed", req.Segment)}
+	}
+
+	if len(l.AllowedProducts) > 0 && !slices.Contains(l.AllowedProducts, req.Product) {
+		return 0, RiskError{RiskRuleProduct, fmt.Sprintf("product %s is not allowed", req.Product)}
+	}
+
+	g.mu.Lock()
+	g.rollover()
+	pnl := g.dailyPnl
+	g.mu.Unlock()
+
+	if l.MaxDailyLoss > 0 && pnl <= -l.MaxDailyLoss {
+		return 0, RiskError{RiskRuleDailyLoss, fmt.Sprintf("daily pnl %.2f breached the loss limit %.2f", pnl, l.MaxDailyLoss)}
+	}
+
+	maxQuantity, ok := l.MaxQuantity[req.TradingSymbol]
+	if !ok {
+		maxQuantity = l.DefaultMaxQuantity
+	}
+
+	if maxQuantity > 0 && req.Quantity > maxQuantity {
+		return 0, RiskError{RiskRuleQuantity, fmt.Sprintf("quantity %d exceeds %d for %s", req.Quantity, maxQuantity, req.TradingSymbol)}
+	}
+
+	price := notionalPrice(req, 0)
+
+	needsQuote := l.CheckPriceBand || l.MaxLtpDeviation > 0 ||
+		(req.OrderType == OrderTypeMarket && (l.MaxOrderNotional > 0 || l.MaxDailyNotional > 0))
+
+	if needsQuote {
+		quote, err := g.quotes.GetQuote(ctx, QuoteRequest{Exchange: req.Exchange, Segment: req.Segment, TradingSymbol: req.TradingSymbol})
+		if err != nil {
+			return 0, fmt.Errorf("GetQuote(%s): %w", req.TradingSymbol, err)
+		}
+
+		price = notionalPrice(req, quote.LastPrice)
+
+		if err := checkQuote(l, req, price, quote); err != nil {
+			return 0, err
+		}
+	}
+
+	notional := float64(req.Quantity) * float64(price)
+	if l.MaxOrderNotional > 0 && notional > l.MaxOrderNotional {
+		return 0, RiskError{RiskRuleOrderNotional, fmt.Sprintf("notional %.2f exceeds %.2f", notional, l.MaxOrderNotional)}
+	}
+
+	return notional, nil
+}
+
+// notional returns the notional value of an order, fetching the last traded price for MARKET orders
+func (g *RiskGate) notional(ctx context.Context, req PlaceOrderRequest) (float64, error) {
+	var ltp float32
+	if req.OrderType == OrderTypeMarket {
+		quote, err := g.quotes.GetQuote(ctx, QuoteRequest{Exchange: req.Exchange, Segment: req.Segment, TradingSymbol: req.TradingSymbol})
+		if err != nil {
+			return 0, fmt.Errorf("GetQuote(%s): %w", req.TradingSymbol, err)
+		}
+
+		ltp = quote.LastPrice
+	}
+
+	return float64(req.Quantity) * float64(notionalPrice(req, ltp)), nil
+}
+
+// notionalPrice returns the price an order is valued at: the last traded price ltp for MARKET orders,
+// the trigger price for SL_M orders and the limit price otherwise
+func notionalPrice(req PlaceOrderRequest, ltp float32) float32 {
+	switch req.OrderType {
+	case OrderTypeMarket:
+		return ltp
+	case OrderTypeStopLossMarket:
+		return req.TriggerPrice
+	default:
+		return req.Price
+	}
+}
+
+func checkQuote(l RiskLimits, req PlaceOrderRequest, price float32, quote Quote) error {
+	prices := []float32{price}
+	if req.TriggerPrice != 0 && req.TriggerPrice != price {
+		prices = append(prices, req.TriggerPrice)
+	}
+
+	for _, p := range prices {
+		if l.CheckPriceBand && quote.UpperCircuitLimit > 0 && (p > quote.UpperCircuitLimit || p < quote.LowerCircuitLimit) {
+			return RiskError{RiskRulePriceBand, fmt.Sprintf(
+				"price %v is outside circuit limits [%v, %v]", p, quote.LowerCircuitLimit, quote.UpperCircuitLimit,
+			)}
+		}
+
+		if l.MaxLtpDeviation > 0 && quote.LastPrice > 0 {
+			deviation := math.Abs(float64(p-quote.LastPrice)) / float64(quote.LastPrice)
+			if deviation > l.MaxLtpDeviation {
+				return RiskError{RiskRuleLtpDeviation, fmt.Sprintf(
+					"price %v deviates %.2f%% from ltp %v", p, deviation*100, quote.LastPrice,
+				)}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (g *RiskGate) openOrders(ctx context.Context) (int, error) {
+	open := 0
+	for order, err := range AllOrders(ctx, g.OrdersAPI, "") {
+		if err != nil {
+			return 0, fmt.Errorf("AllOrders: %w", err)
+		}
+
+		if order.OrderStatus.IsOpen() {
+			open++
+		}
+	}
+
+	return open, nil
+}
+
+// reserve checks the daily notional limit and adds notional to the day's total.
+// Returns the day it was reserved on, to release it if the order fails. Must be called with g.mu held
+func (g *RiskGate) reserve(notional float64) (string, error) {
+	g.rollover()
+
+	if g.limits.MaxDailyNotional > 0 && g.dailyNotional+notional > g.limits.MaxDailyNotional {
+		return "", RiskError{RiskRuleDailyNotional, fmt.Sprintf(
+			"daily notional %.2f with order %.2f exceeds %.2f", g.dailyNotional, notional, g.limits.MaxDailyNotional,
+		)}
+	}
+
+	g.dailyNotional += notional
+	return g.day, nil
+}
+
+// release gives back notional reserved on day, unless the counters were reset since. Must be called with g.mu held
+func (g *RiskGate) release(day string, notional float64) {
+	g.rollover()
+
+	if g.day == day {
+		g.dailyNotional -= notional
+	}
+}
+
+// rollover resets the daily counters when the day changes. Must be called with g.mu held
+func (g *RiskGate) rollover() {
+	today := time.Now().In(IST).Format(time.DateOnly)
+	if g.day == today {
+		return
+	}
+
+	g.day = today
+	g.dailyNotional = 0
+	g.dailyPnl = 0
+	clear(g.orders)
+}
@@ -6,6 +6,19 @@ import (
 	"time"
 )
 
+// IST is Asia/Kolkata, the time zone all Groww timestamps are in.
+// Falls back to a fixed +05:30 zone on hosts without the tz database; India has no daylight saving time.
+var IST = loadIST()
+
+func loadIST() *time.Location {
+	loc, err := time.LoadLocation("Asia/Kolkata")
+	if err != nil {
+		return time.FixedZone("IST", 5*3600+1800)
+	}
+
+	return loc
+}
+
 // NullableTime represents a nullable version of Time
 // See Time for more details
 type NullableTime struct {
@@ -13,14 +26,15 @@ type NullableTime struct {
 }
 
 // Time represents a time coming from groww apis.
-// There are multiple representations for the same time present in APIs. This aims to parse all variations properly
+// There are multiple representations for the same time present in APIs. This aims to parse all variations properly.
+// Times without a zone are taken to be in IST, and all parsed times are returned in IST.
 type Time struct {
 	time.Time
 }
 
 func (t *Time) UnmarshalCSV(in string) error {
 	// csv only received time.DateOnly
-	parsed, err := time.Parse(time.DateOnly, in)
+	parsed, err := time.ParseInLocation(time.DateOnly, in, IST)
 	if err != nil {
 		return fmt.Errorf("time.Parse(%q): %w", in, err)
 	}
@@ -29,11 +43,21 @@ func (t *Time) UnmarshalCSV(in string) error {
 	return nil
 }
 
+// MarshalCSV formats the date in IST as time.DateOnly, the way it is received
+func (t Time) MarshalCSV() (string, error) {
+	return t.In(IST).Format(time.DateOnly), nil
+}
+
 func (t *Time) UnmarshalJSON(bytes []byte) error {
+	if string(bytes) == "null" {
+		t.Time = time.Time{}
+		return nil
+	}
+
 	// check if it's an integer. In that case, parse it as epoch
 	var epoch int64
 	if err := json.Unmarshal(bytes, &epoch); err == nil {
-		t.Time = time.Unix(epoch, 0)
+		t.Time = time.Unix(epoch, 0).In(IST)
 		return nil
 	}
 
@@ -42,18 +66,21 @@ func (t *Time) UnmarshalJSON(bytes []byte) error {
 		return fmt.Errorf("json.Unmarshal(%q): cannot parse as time", string(bytes))
 	}
 
-	layouts := []string{
-		time.RFC3339,
-		time.DateTime,
-		time.DateOnly,
-		"2006-01-02T15:04:05Z",
-		"2006-01-02T15:04:05",
+	layouts := []struct {
+		layout string
+		loc    *time.Location
+	}{
+		{time.RFC3339, IST},
+		{time.DateTime, IST},
+		{time.DateOnly, IST},
+		{"2006-01-02T15:04:05Z", time.UTC},
+		{"2006-01-02T15:04:05", IST},
 	}
 
-	for _, layout := range layouts {
-		parsed, err := time.Parse(layout, asString)
+	for _, l := range layouts {
+		parsed, err := time.ParseInLocation(l.layout, asString, l.loc)
 		if err == nil {
-			t.Time = parsed
+			t.Time = parsed.In(IST)
 			return nil
 		}
 	}
@@ -61,6 +88,16 @@ func (t *Time) UnmarshalJSON(bytes []byte) error {
 	return fmt.Errorf("cannot parse as time: %q", asString)
 }
 
+// MarshalJSON formats the time in IST as time.RFC3339Nano, which Time.UnmarshalJSON parses back to the same instant.
+// The zero Time is formatted as null, which is parsed back to the zero Time.
+func (t Time) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(t.In(IST).Format(time.RFC3339Nano))
+}
+
 func (t *NullableTime) UnmarshalCSV(in string) error {
 	if in == "" {
 		t.Time = nil
@@ -75,3 +112,36 @@ func (t *NullableTime) UnmarshalCSV(in string) error {
 	t.Time = &parsed.Time
 	return nil
 }
+
+// MarshalCSV formats the date like Time.MarshalCSV, or as an empty string if it is null
+func (t NullableTime) MarshalCSV() (string, error) {
+	if t.Time == nil {
+		return "", nil
+	}
+
+	return Time{*t.Time}.MarshalCSV()
+}
+
+func (t *NullableTime) UnmarshalJSON(bytes []byte) error {
+	if string(bytes) == "null" {
+		t.Time = nil
+		return nil
+	}
+
+	var parsed Time
+	if err := parsed.UnmarshalJSON(bytes); err != nil {
+		return err
+	}
+
+	t.Time = &parsed.Time
+	return nil
+}
+
+// MarshalJSON formats the time like Time.MarshalJSON, or as null
+func (t NullableTime) MarshalJSON() ([]byte, error) {
+	if t.Time == nil {
+		return []byte("null"), nil
+	}
+
+	return Time{*t.Time}.MarshalJSON()
+}
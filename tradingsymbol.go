@@ -0,0 +1,340 @@
+package growwapi
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Exchange trading symbols of derivatives come in two formats:
+//
+//	monthly: NIFTY25APRFUT, NIFTY25APR24100PE     (underlying, YY, MMM, strike, option type)
+//	weekly:  NIFTY2541724100PE, NIFTY25N0624100CE  (underlying, YY, M, DD, strike, option type)
+//
+// where M is the month number for January to September, and O, N, D for October, November and December.
+// Futures always use the monthly format.
+var (
+	monthlyFutureSymbol = regexp.MustCompile(`^(.+)(\d{2})(JAN|FEB|MAR|APR|MAY|JUN|JUL|AUG|SEP|OCT|NOV|DEC)FUT$`)
+	monthlyOptionSymbol = regexp.MustCompile(`^(.+)(\d{2})(JAN|FEB|MAR|APR|MAY|JUN|JUL|AUG|SEP|OCT|NOV|DEC)(\d+(?:\.\d+)?)(CE|PE)$`)
+	// weekly option symbols are matched without the underlying, see parseWeeklyOptionSymbol
+	weeklyOptionSuffix = regexp.MustCompile(`^(\d{2})([1-9OND])(\d{2})(\d+(?:\.\d+)?)(CE|PE)$`)
+	// exchange trading symbols are upper case, e.g. M&M, BAJAJ-AUTO
+	tradingSymbolCharacters = regexp.MustCompile(`^[A-Z0-9&.-]+$`)
+)
+
+const weeklyMonthCodes = "123456789OND"
+
+type expiryWeekday struct {
+	// first month the weekday applies to
+	from    time.Time
+	weekday time.Weekday
+}
+
+// monthlyExpiryWeekdays lists the weekdays monthly contracts expire on by exchange, ordered by the month they took
+// effect from. NSE moved from Thursday to Tuesday in September 2025, while BSE moved from Friday to Tuesday in
+// January 2025 and to Thursday in September 2025.
+var monthlyExpiryWeekdays = map[Exchange][]expiryWeekday{
+	ExchangeNse: {
+		{time.Time{}, time.Thursday},
+		{time.Date(2025, time.September, 1, 0, 0, 0, 0, IST), time.Tuesday},
+	},
+	ExchangeBse: {
+		{time.Time{}, time.Thursday},
+		{time.Date(2023, time.May, 1, 0, 0, 0, 0, IST), time.Friday},
+		{time.Date(2025, time.January, 1, 0, 0, 0, 0, IST), time.Tuesday},
+		{time.Date(2025, time.September, 1, 0, 0, 0, 0, IST), time.Thursday},
+	},
+}
+
+// TradingSymbolOptions represents the options for ParseTradingSymbolWith
+type TradingSymbolOptions struct {
+	// [Optional] Expiry calendars of underlyings. The expiry of a symbol whose underlying has a calendar is taken from
+	// it: the monthly expiry of the month for monthly symbols, and for ambiguous weekly symbols the reading which is an
+	// actual expiry is preferred.
+	Expiries []*ExpiryCalendar
+	// [Optional] Used to move expected monthly expiries which fall on a holiday to the previous trading day,
+	// for underlyings without an expiry calendar. Defaults to DefaultMarketCalendar
+	Calendar *MarketCalendar
+	// [Optional] Time around which ambiguous weekly symbols are resolved. Defaults to time.Now
+	Now time.Time
+}
+
+func (o TradingSymbolOptions) withDefaults() TradingSymbolOptions {
+	if o.Calendar == nil {
+		o.Calendar = DefaultMarketCalendar()
+	}
+
+	if o.Now.IsZero() {
+		o.Now = time.Now()
+	}
+
+	return o
+}
+
+func (o TradingSymbolOptions) expiries(exchange Exchange, underlying string) *ExpiryCalendar {
+	for _, calendar := range o.Expiries {
+		if calendar.Exchange() == exchange && calendar.Underlying() == underlying {
+			return calendar
+		}
+	}
+
+	return nil
+}
+
+// IsMonthlyExpiry reports whether expiry is the last expiry of its month, which is the one using the monthly format in
+// exchange trading symbols. An expiry is considered monthly when the same weekday of the next week falls in the next
+// month, which also holds when a holiday moves the monthly expiry a day or two earlier. Use ExpiryCalendar.Expiry
+// where the actual expiries are known.
+func IsMonthlyExpiry(expiry time.Time) bool {
+	return expiry.AddDate(0, 0, 7).Month() != expiry.Month()
+}
+
+// TradingSymbol generates the exchange trading symbol, as used by PlaceOrderRequest.TradingSymbol.
+// Options use the monthly or weekly format as decided by IsMonthlyExpiry.
+func (p GrowwSymbolParts) TradingSymbol() string {
+	return p.tradingSymbol(IsMonthlyExpiry(p.Expiry))
+}
+
+// TradingSymbolWith generates the exchange trading symbol like GrowwSymbolParts.TradingSymbol, but options use the
+// monthly format if calendar has their expiry as monthly. IsMonthlyExpiry decides for expiries missing from calendar.
+func (p GrowwSymbolParts) TradingSymbolWith(calendar *ExpiryCalendar) string {
+	if calendar != nil {
+		if expiry, ok := calendar.Expiry(p.Expiry); ok {
+			return p.tradingSymbol(expiry.Kind.IsMonthly())
+		}
+	}
+
+	return p.TradingSymbol()
+}
+
+func (p GrowwSymbolParts) tradingSymbol(monthly bool) string {
+	switch p.InstrumentType {
+	case InstrumentTypeFutures:
+		return fmt.Sprintf("%s%s%sFUT", p.Underlying, p.Expiry.Format("06"), strings.ToUpper(p.Expiry.Format("Jan")))
+
+	case InstrumentTypeCallOption, InstrumentTypePutOption:
+		strike := strconv.FormatFloat(float64(p.StrikePrice), 'f', -1, 32)
+
+		if monthly {
+			return fmt.Sprintf("%s%s%s%s%s",
+				p.Underlying, p.Expiry.Format("06"), strings.ToUpper(p.Expiry.Format("Jan")), strike, p.OptionType)
+		}
+
+		return fmt.Sprintf("%s%s%c%s%s%s",
+			p.Underlying, p.Expiry.Format("06"), weeklyMonthCodes[p.Expiry.Month()-1], p.Expiry.Format("02"), strike, p.OptionType)
+
+	default:
+		return p.Underlying
+	}
+}
+
+// ExchangeSymbol generates the exchange symbol, as used by LtpRequest.ExchangeSymbols and OhlcRequest.ExchangeSymbols,
+// e.g. NSE_NIFTY25APR24100PE
+func (p GrowwSymbolParts) ExchangeSymbol() string {
+	return fmt.Sprintf("%s_%s", p.Exchange, p.TradingSymbol())
+}
+
+// ParseTradingSymbol decomposes an exchange trading symbol into its parts. Symbols without a derivative suffix are
+// parsed as InstrumentTypeEquity.
+//
+// Monthly futures and options are expected to expire on the last expiry weekday of the month for the exchange, moved
+// to the previous trading day if that is a holiday, since the trading symbol does not carry the day. Use
+// ParseTradingSymbolWith with the expiry calendar of the underlying, or the Instrument the symbol belongs to, for the
+// actual expiry date when available.
+//
+// The weekly format is ambiguous for underlyings ending in digits, e.g. NIFTYNXT50. Among the valid readings the one
+// with the expiry closest to now is chosen.
+//
+// Symbols which are empty, contain characters other than upper case letters, digits, '&', '-' and '.', or are options
+// with a zero strike price are rejected.
+func ParseTradingSymbol(exchange Exchange, tradingSymbol string) (GrowwSymbolParts, error) {
+	return ParseTradingSymbolWith(exchange, tradingSymbol, TradingSymbolOptions{})
+}
+
+// ParseTradingSymbolWith is ParseTradingSymbol taking the expiries, holidays and current time from opts
+func ParseTradingSymbolWith(exchange Exchange, tradingSymbol string, opts TradingSymbolOptions) (GrowwSymbolParts, error) {
+	opts = opts.withDefaults()
+	out := GrowwSymbolParts{Exchange: exchange}
+
+	if !tradingSymbolCharacters.MatchString(tradingSymbol) {
+		return GrowwSymbolParts{}, fmt.Errorf("invalid trading symbol %q", tradingSymbol)
+	}
+
+	if m := monthlyFutureSymbol.FindStringSubmatch(tradingSymbol); m != nil {
+		expiry, err := monthlyExpiry(exchange, m[1], m[2], m[3], opts)
+		if err != nil {
+			return GrowwSymbolParts{}, fmt.Errorf("invalid trading symbol %q: %w", tradingSymbol, err)
+		}
+
+		out.Underlying = m[1]
+		out.InstrumentType = InstrumentTypeFutures
+		out.Expiry = expiry
+		return out, nil
+	}
+
+	if m := monthlyOptionSymbol.FindStringSubmatch(tradingSymbol); m != nil {
+		expiry, err := monthlyExpiry(exchange, m[1], m[2], m[3], opts)
+		if err != nil {
+			return GrowwSymbolParts{}, fmt.Errorf("invalid trading symbol %q: %w", tradingSymbol, err)
+		}
+
+		strike, err := strconv.ParseFloat(m[4], 32)
+		if err != nil {
+			return GrowwSymbolParts{}, fmt.Errorf("invalid trading symbol %q: strike price: %w", tradingSymbol, err)
+		}
+
+		if strike == 0 {
+			return GrowwSymbolParts{}, fmt.Errorf("invalid trading symbol %q: zero strike price", tradingSymbol)
+		}
+
+		out.Underlying = m[1]
+		out.InstrumentType = InstrumentType(m[5])
+		out.Expiry = expiry
+		out.StrikePrice = float32(strike)
+		out.OptionType = OptionType(m[5])
+		return out, nil
+	}
+
+	if parts, ok := parseWeeklyOptionSymbol(exchange, tradingSymbol, opts); ok {
+		parts.Exchange = exchange
+		return parts, nil
+	}
+
+	out.Underlying = tradingSymbol
+	out.InstrumentType = InstrumentTypeEquity
+	return out, nil
+}
+
+// parseWeeklyOptionSymbol tries every split of the underlying and the expiry. Readings whose expiry is in the expiry
+// calendar of their underlying win, and among equals the one closest to opts.Now is kept.
+func parseWeeklyOptionSymbol(exchange Exchange, tradingSymbol string, opts TradingSymbolOptions) (GrowwSymbolParts, bool) {
+	var best GrowwSymbolParts
+	found, bestKnown := false, false
+	now := opts.Now
+
+	for end := 1; end < len(tradingSymbol); end++ {
+		m := weeklyOptionSuffix.FindStringSubmatch(tradingSymbol[end:])
+		if m == nil {
+			continue
+		}
+
+		year, _ := strconv.Atoi(m[1])
+		day, _ := strconv.Atoi(m[3])
+		month := time.Month(strings.IndexByte(weeklyMonthCodes, m[2][0]) + 1)
+
+		expiry := time.Date(2000+year, month, day, 0, 0, 0, 0, IST)
+		if expiry.Day() != day || expiry.Month() != month {
+			continue
+		}
+
+		strike, err := strconv.ParseFloat(m[4], 32)
+		if err != nil || strike == 0 {
+			continue
+		}
+
+		calendar := opts.expiries(exchange, tradingSymbol[:end])
+		known := calendar != nil && calendar.IsExpiryDay(expiry)
+
+		if found && (bestKnown && !known ||
+			bestKnown == known && absDuration(expiry.Sub(now)) >= absDuration(best.Expiry.Sub(now))) {
+			continue
+		}
+
+		found, bestKnown = true, known
+		best = GrowwSymbolParts{
+			Underlying:     tradingSymbol[:end],
+			InstrumentType: InstrumentType(m[5]),
+			Expiry:         expiry,
+			StrikePrice:    float32(strike),
+			OptionType:     OptionType(m[5]),
+		}
+	}
+
+	return best, found
+}
+
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+
+	return d
+}
+
+// monthlyExpiry returns the monthly expiry of the month from the expiry calendar of the underlying if it is in opts,
+// or else the last expiry weekday of the month, moved to the previous trading day if that is a holiday
+func monthlyExpiry(exchange Exchange, underlying, year, month string, opts TradingSymbolOptions) (time.Time, error) {
+	parsed, err := time.ParseInLocation("06Jan", year+month, IST)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("expiry: %w", err)
+	}
+
+	if calendar := opts.expiries(exchange, underlying); calendar != nil {
+		if expiry, ok := calendar.NextMonthly(parsed); ok && expiry.Date.Month() == parsed.Month() {
+			return expiry.Date, nil
+		}
+	}
+
+	weekday := time.Thursday
+	for _, w := range monthlyExpiryWeekdays[exchange] {
+		if !parsed.Before(w.from) {
+			weekday = w.weekday
+		}
+	}
+
+	last := parsed.AddDate(0, 1, -1)
+	expiry := last.AddDate(0, 0, -((int(last.Weekday()) - int(weekday) + 7) % 7))
+
+	// a holiday moves the expiry to the previous trading day, as long as that is still in the month
+	if !opts.Calendar.IsTradingDay(exchange, SegmentFno, expiry) {
+		if previous, ok := opts.Calendar.PreviousTradingDay(exchange, SegmentFno, expiry); ok && previous.Month() == expiry.Month() {
+			expiry = previous
+		}
+	}
+
+	return expiry, nil
+}
+
+// ParseExchangeSymbol decomposes an exchange symbol such as NSE_NIFTY25APR24100PE into its parts.
+// See ParseTradingSymbol for the caveats of parsing trading symbols.
+func ParseExchangeSymbol(exchangeSymbol string) (GrowwSymbolParts, error) {
+	exchange, tradingSymbol, ok := strings.Cut(exchangeSymbol, "_")
+	if !ok || exchange == "" {
+		return GrowwSymbolParts{}, fmt.Errorf("invalid exchange symbol %q: expected EXCHANGE_SYMBOL", exchangeSymbol)
+	}
+
+	return ParseTradingSymbol(Exchange(exchange), tradingSymbol)
+}
+
+// SymbolParts returns the parts of the instrument, from which all of its symbol formats can be generated
+func (i Instrument) SymbolParts() GrowwSymbolParts {
+	out := GrowwSymbolParts{
+		Exchange:       i.Exchange,
+		Underlying:     i.TradingSymbol,
+		InstrumentType: i.InstrumentType,
+	}
+
+	if i.UnderlyingSymbol != "" {
+		out.Underlying = i.UnderlyingSymbol
+	}
+
+	if i.ExpiryDate.Time != nil {
+		year, month, day := i.ExpiryDate.Date()
+		out.Expiry = time.Date(year, month, day, 0, 0, 0, 0, IST)
+	}
+
+	if i.InstrumentType == InstrumentTypeCallOption || i.InstrumentType == InstrumentTypePutOption {
+		out.StrikePrice = float32(i.StrikePrice)
+		out.OptionType = OptionType(i.InstrumentType)
+	}
+
+	return out
+}
+
+// ExchangeSymbol returns the exchange symbol of the instrument, as used by LtpRequest.ExchangeSymbols and
+// OhlcRequest.ExchangeSymbols, e.g. NSE_RELIANCE
+func (i Instrument) ExchangeSymbol() string {
+	return fmt.Sprintf("%s_%s", i.Exchange, i.TradingSymbol)
+}
@@ -0,0 +1,271 @@
+package growwapi
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTradingSymbol(t *testing.T) {
+	now := time.Date(2025, time.April, 1, 10, 0, 0, 0, IST)
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, IST)
+	}
+
+	tests := []struct {
+		exchange Exchange
+		symbol   string
+		want     GrowwSymbolParts
+	}{
+		{
+			exchange: ExchangeNse,
+			symbol:   "RELIANCE",
+			want:     GrowwSymbolParts{Exchange: ExchangeNse, Underlying: "RELIANCE", InstrumentType: InstrumentTypeEquity},
+		},
+		{
+			exchange: ExchangeNse,
+			symbol:   "M&M",
+			want:     GrowwSymbolParts{Exchange: ExchangeNse, Underlying: "M&M", InstrumentType: InstrumentTypeEquity},
+		},
+		{
+			// monthly future on the last Thursday, before NSE moved to Tuesday
+			exchange: ExchangeNse,
+			symbol:   "NIFTY25AUGFUT",
+			want: GrowwSymbolParts{
+				Exchange:       ExchangeNse,
+				Underlying:     "NIFTY",
+				InstrumentType: InstrumentTypeFutures,
+				Expiry:         date(2025, time.August, 28),
+			},
+		},
+		{
+			// monthly future on the last Tuesday
+			exchange: ExchangeNse,
+			symbol:   "NIFTY25OCTFUT",
+			want: GrowwSymbolParts{
+				Exchange:       ExchangeNse,
+				Underlying:     "NIFTY",
+				InstrumentType: InstrumentTypeFutures,
+				Expiry:         date(2025, time.October, 28),
+			},
+		},
+		{
+			// the last Tuesday is a holiday, the expiry moves to the previous trading day
+			exchange: ExchangeNse,
+			symbol:   "NIFTY26MARFUT",
+			want: GrowwSymbolParts{
+				Exchange:       ExchangeNse,
+				Underlying:     "NIFTY",
+				InstrumentType: InstrumentTypeFutures,
+				Expiry:         date(2026, time.March, 30),
+			},
+		},
+		{
+			// monthly future on the last Friday, before BSE moved to Tuesday
+			exchange: ExchangeBse,
+			symbol:   "SENSEX24DECFUT",
+			want: GrowwSymbolParts{
+				Exchange:       ExchangeBse,
+				Underlying:     "SENSEX",
+				InstrumentType: InstrumentTypeFutures,
+				Expiry:         date(2024, time.December, 27),
+			},
+		},
+		{
+			exchange: ExchangeNse,
+			symbol:   "NIFTY25APR24100PE",
+			want: GrowwSymbolParts{
+				Exchange:       ExchangeNse,
+				Underlying:     "NIFTY",
+				InstrumentType: InstrumentTypePutOption,
+				Expiry:         date(2025, time.April, 24),
+				StrikePrice:    24100,
+				OptionType:     OptionTypePE,
+			},
+		},
+		{
+			exchange: ExchangeNse,
+			symbol:   "BANKNIFTY24DEC52000CE",
+			want: GrowwSymbolParts{
+				Exchange:       ExchangeNse,
+				Underlying:     "BANKNIFTY",
+				InstrumentType: InstrumentTypeCallOption,
+				Expiry:         date(2024, time.December, 26),
+				StrikePrice:    52000,
+				OptionType:     OptionTypeCE,
+			},
+		},
+		{
+			exchange: ExchangeNse,
+			symbol:   "NIFTY2541724100PE",
+			want: GrowwSymbolParts{
+				Exchange:       ExchangeNse,
+				Underlying:     "NIFTY",
+				InstrumentType: InstrumentTypePutOption,
+				Expiry:         date(2025, time.April, 17),
+				StrikePrice:    24100,
+				OptionType:     OptionTypePE,
+			},
+		},
+		{
+			// months from October to December are coded as O, N and D
+			exchange: ExchangeNse,
+			symbol:   "NIFTY25N0426000CE",
+			want: GrowwSymbolParts{
+				Exchange:       ExchangeNse,
+				Underlying:     "NIFTY",
+				InstrumentType: InstrumentTypeCallOption,
+				Expiry:         date(2025, time.November, 4),
+				StrikePrice:    26000,
+				OptionType:     OptionTypeCE,
+			},
+		},
+		{
+			// also readable as NIFTYNXT502 expiring on 2054-01-07, which is further from now
+			exchange: ExchangeNse,
+			symbol:   "NIFTYNXT502541071000CE",
+			want: GrowwSymbolParts{
+				Exchange:       ExchangeNse,
+				Underlying:     "NIFTYNXT50",
+				InstrumentType: InstrumentTypeCallOption,
+				Expiry:         date(2025, time.April, 10),
+				StrikePrice:    71000,
+				OptionType:     OptionTypeCE,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.symbol, func(t *testing.T) {
+			got, err := ParseTradingSymbolWith(tt.exchange, tt.symbol, TradingSymbolOptions{Now: now})
+			if err != nil {
+				t.Fatalf("ParseTradingSymbolWith() error = %v", err)
+			}
+
+			if !got.Expiry.Equal(tt.want.Expiry) {
+				t.Errorf("Expiry = %v, want %v", got.Expiry, tt.want.Expiry)
+			}
+
+			got.Expiry, tt.want.Expiry = time.Time{}, time.Time{}
+			if got != tt.want {
+				t.Errorf("ParseTradingSymbolWith() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTradingSymbolAmbiguousWeekly(t *testing.T) {
+	const symbol = "NIFTYNXT502541071000CE"
+	later := time.Date(2054, time.January, 1, 10, 0, 0, 0, IST)
+
+	tests := []struct {
+		name       string
+		opts       TradingSymbolOptions
+		underlying string
+		expiry     time.Time
+		strike     float32
+	}{
+		{
+			name:       "closest to now",
+			opts:       TradingSymbolOptions{Now: later},
+			underlying: "NIFTYNXT502",
+			expiry:     time.Date(2054, time.January, 7, 0, 0, 0, 0, IST),
+			strike:     1000,
+		},
+		{
+			name: "actual expiry",
+			opts: TradingSymbolOptions{
+				Now: later,
+				Expiries: []*ExpiryCalendar{
+					NewExpiryCalendar(ExchangeNse, "NIFTYNXT50", []time.Time{time.Date(2025, time.April, 10, 0, 0, 0, 0, IST)}, nil),
+				},
+			},
+			underlying: "NIFTYNXT50",
+			expiry:     time.Date(2025, time.April, 10, 0, 0, 0, 0, IST),
+			strike:     71000,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseTradingSymbolWith(ExchangeNse, symbol, tt.opts)
+			if err != nil {
+				t.Fatalf("ParseTradingSymbolWith() error = %v", err)
+			}
+
+			if got.Underlying != tt.underlying || !got.Expiry.Equal(tt.expiry) || got.StrikePrice != tt.strike {
+				t.Errorf("ParseTradingSymbolWith() = %+v, want %s expiring %v with strike %v", got, tt.underlying, tt.expiry, tt.strike)
+			}
+		})
+	}
+}
+
+func TestParseTradingSymbolInvalid(t *testing.T) {
+	tests := []string{
+		"",
+		"nifty25aprfut",
+		"NIFTY 25APRFUT",
+		"NSE_NIFTY",
+		"NIFTY25APR0CE",
+	}
+
+	for _, symbol := range tests {
+		t.Run(symbol, func(t *testing.T) {
+			if got, err := ParseTradingSymbol(ExchangeNse, symbol); err == nil {
+				t.Errorf("ParseTradingSymbol() = %+v, want error", got)
+			}
+		})
+	}
+}
+
+func TestTradingSymbolRoundTrip(t *testing.T) {
+	tests := []struct {
+		exchange Exchange
+		symbol   string
+	}{
+		{ExchangeNse, "RELIANCE"},
+		{ExchangeNse, "NIFTY25AUGFUT"},
+		{ExchangeNse, "NIFTY26MARFUT"},
+		{ExchangeBse, "SENSEX24DECFUT"},
+		{ExchangeNse, "NIFTY25APR24100PE"},
+		{ExchangeNse, "NIFTY2541724100PE"},
+		{ExchangeNse, "NIFTY25N0426000CE"},
+		{ExchangeNse, "NIFTY25D0925950.5CE"},
+	}
+
+	opts := TradingSymbolOptions{Now: time.Date(2025, time.April, 1, 10, 0, 0, 0, IST)}
+	for _, tt := range tests {
+		t.Run(tt.symbol, func(t *testing.T) {
+			parts, err := ParseTradingSymbolWith(tt.exchange, tt.symbol, opts)
+			if err != nil {
+				t.Fatalf("ParseTradingSymbolWith() error = %v", err)
+			}
+
+			if got := parts.TradingSymbol(); got != tt.symbol {
+				t.Errorf("TradingSymbol() = %q, want %q", got, tt.symbol)
+			}
+		})
+	}
+}
+
+func TestTradingSymbolWith(t *testing.T) {
+	// the 23rd is the last expiry of the month in calendar, while IsMonthlyExpiry considers it weekly
+	expiry := time.Date(2025, time.January, 23, 0, 0, 0, 0, IST)
+	calendar := NewExpiryCalendar(ExchangeNse, "NIFTY", []time.Time{time.Date(2025, time.January, 16, 0, 0, 0, 0, IST), expiry}, nil)
+
+	parts := GrowwSymbolParts{
+		Exchange:       ExchangeNse,
+		Underlying:     "NIFTY",
+		InstrumentType: InstrumentTypeCallOption,
+		Expiry:         expiry,
+		StrikePrice:    23000,
+		OptionType:     OptionTypeCE,
+	}
+
+	if got, want := parts.TradingSymbol(), "NIFTY2512323000CE"; got != want {
+		t.Errorf("TradingSymbol() = %q, want %q", got, want)
+	}
+
+	if got, want := parts.TradingSymbolWith(calendar), "NIFTY25JAN23000CE"; got != want {
+		t.Errorf("TradingSymbolWith() = %q, want %q", got, want)
+	}
+}